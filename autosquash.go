@@ -0,0 +1,64 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+type AutosquashKind string
+
+const (
+	AutosquashFixup  AutosquashKind = "fixup"
+	AutosquashSquash AutosquashKind = "squash"
+	AutosquashAmend  AutosquashKind = "amend"
+)
+
+// Autosquash describes a `fixup!`, `squash!` or `amend!` commit
+// meant for `git rebase --autosquash`
+type Autosquash struct {
+	Kind AutosquashKind
+	// Target is the header of the commit to squash into, without any prefix
+	Target Header
+}
+
+var autosquashPattern = regexp.MustCompile(`^(fixup|squash|amend)!\s+(.*)$`)
+
+// ParseAutosquash returns the autosquash described by the header, or nil
+func (m *Message) ParseAutosquash() *Autosquash {
+	matcher := autosquashPattern.FindStringSubmatch(strings.TrimSpace(m.Header))
+	if matcher == nil {
+		return nil
+	}
+
+	autosquash := Autosquash{Kind: AutosquashKind(matcher[1])}
+	target := matcher[2]
+
+	// `fixup! fixup! feat: x` targets `feat: x`
+	for {
+		inner := autosquashPattern.FindStringSubmatch(target)
+		if inner == nil {
+			break
+		}
+
+		target = inner[2]
+	}
+
+	autosquash.Target = m.headerParser().Parse(target)
+
+	return &autosquash
+}
+
+// NoAutosquashRule reports autosquash commits, for the commits of protected
+// ranges such as a pre-push hook or a pull request check
+func NoAutosquashRule() LintRule {
+	return LintRule{
+		Name: "no-autosquash",
+		Check: func(c *Commit) []string {
+			if autosquash := c.Message.ParseAutosquash(); autosquash != nil {
+				return []string{string(autosquash.Kind) + "! commits must be squashed with `git rebase --autosquash` before merging"}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAutosquash(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *Autosquash
+	}{
+		{
+			name:    "not an autosquash",
+			message: "feat(api)!: add search",
+			want:    nil,
+		},
+		{
+			name:    "fixup",
+			message: "fixup! feat(api): add search",
+			want:    &Autosquash{Kind: AutosquashFixup, Target: Header{Type: "feat", Scope: "api", Subject: "add search"}},
+		},
+		{
+			name:    "squash",
+			message: "squash! fix: typo\n\nmore details",
+			want:    &Autosquash{Kind: AutosquashSquash, Target: Header{Type: "fix", Subject: "typo"}},
+		},
+		{
+			name:    "amend",
+			message: "amend! feat(api)!: add search",
+			want:    &Autosquash{Kind: AutosquashAmend, Target: Header{Type: "feat", Scope: "api", Subject: "add search", Important: true}},
+		},
+		{
+			name:    "nested",
+			message: "fixup! squash! fix: typo",
+			want:    &Autosquash{Kind: AutosquashFixup, Target: Header{Type: "fix", Subject: "typo"}},
+		},
+		{
+			name:    "non conventional target",
+			message: "fixup! add search",
+			want:    &Autosquash{Kind: AutosquashFixup, Target: Header{Subject: "add search"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.message).ParseAutosquash())
+		})
+	}
+}
+
+func TestNoAutosquashRule(t *testing.T) {
+	rule := NoAutosquashRule()
+
+	assert.Equal(t, []LintProblem{}, Lint(&Commit{Message: Parse("feat: add search")}, rule))
+	assert.Equal(t, []LintProblem{
+		{Rule: "no-autosquash", Message: "fixup! commits must be squashed with `git rebase --autosquash` before merging"},
+	}, Lint(&Commit{Message: Parse("fixup! feat: add search")}, rule))
+}
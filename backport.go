@@ -0,0 +1,138 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+)
+
+// Backport references the original commit of a cherry-pick or a backport
+type Backport struct {
+	// Hash of the original commit
+	Hash string
+	// Source is `cherry-pick` for the line written by `git cherry-pick -x`,
+	// or the trailer, `Backport-of` or `Upstream-commit`
+	Source string
+}
+
+var (
+	cherryPickPattern      = regexp.MustCompile(`(?m)^\(cherry picked from commit ([0-9a-fA-F]{4,64})\)\s*$`)
+	backportTrailerPattern = regexp.MustCompile(`(?mi)^(Backport-of|Upstream-commit):\s*([0-9a-f]{4,64})\b`)
+)
+
+// ParseBackports returns the original commits of a cherry-pick or a backport
+func (m *Message) ParseBackports() []Backport {
+	txt := m.afterHeader()
+	backports := make([]Backport, 0)
+
+	for _, matcher := range cherryPickPattern.FindAllStringSubmatch(txt, -1) {
+		backports = append(backports, Backport{Hash: matcher[1], Source: "cherry-pick"})
+	}
+
+	for _, matcher := range backportTrailerPattern.FindAllStringSubmatch(txt, -1) {
+		backports = append(backports, Backport{Hash: matcher[2], Source: CanonicalTrailerKey(matcher[1])})
+	}
+
+	return backports
+}
+
+// BackportGroup is a commit along with its backports
+type BackportGroup struct {
+	// Commit is the original commit, or the first backport when the original is missing
+	Commit    *Commit
+	Backports []*Commit
+}
+
+// Releases returns the releases of the backports
+func (g BackportGroup) Releases() []string {
+	releases := make([]string, 0)
+	seen := map[string]bool{"": true, g.Commit.Release: true}
+
+	for _, c := range g.Backports {
+		if !seen[c.Release] {
+			seen[c.Release] = true
+			releases = append(releases, c.Release)
+		}
+	}
+
+	return releases
+}
+
+// GroupBackports links the commits of several branches to their backports,
+// following cherry-picks of cherry-picks. Groups are in the order of their
+// first commit.
+func GroupBackports(commits []*Commit) []BackportGroup {
+	index := newHashIndex(commitHashes(commits))
+
+	find := func(hash string) int {
+		if indexes := index.lookup(hash); len(indexes) != 0 {
+			return indexes[0]
+		}
+
+		return -1
+	}
+
+	// root returns the hash of the original commit
+	root := func(c *Commit) string {
+		hash := c.Hash
+
+		for seen := 0; seen < len(commits); seen++ {
+			backports := c.Message.ParseBackports()
+			if len(backports) == 0 {
+				return hash
+			}
+
+			hash = backports[0].Hash
+
+			i := find(hash)
+			if i == -1 {
+				return hash
+			}
+
+			c = commits[i]
+			hash = c.Hash
+		}
+
+		return hash
+	}
+
+	roots := make([]string, len(commits))
+
+	for i, c := range commits {
+		roots[i] = root(c)
+	}
+
+	rootIndex := newHashIndex(roots)
+	groups := make([]BackportGroup, 0)
+	// groupOf is the group started by each commit, -1 for the others
+	groupOf := make([]int, len(commits))
+
+	for i, c := range commits {
+		r := roots[i]
+		group := -1
+		groupOf[i] = -1
+
+		for _, j := range rootIndex.lookup(r) {
+			if j < i && groupOf[j] != -1 {
+				group = groupOf[j]
+				break
+			}
+		}
+
+		if group == -1 {
+			groupOf[i] = len(groups)
+			groups = append(groups, BackportGroup{Commit: c, Backports: make([]*Commit, 0)})
+			continue
+		}
+
+		g := &groups[group]
+
+		// the original commit leads its group
+		if sameHash(c.Hash, r) {
+			g.Backports = append([]*Commit{g.Commit}, g.Backports...)
+			g.Commit = c
+		} else {
+			g.Backports = append(g.Backports, c)
+		}
+	}
+
+	return groups
+}
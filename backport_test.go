@@ -0,0 +1,64 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBackports(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Backport
+	}{
+		{
+			name:    "not a backport",
+			message: "fix: typo\n\nbody",
+			want:    []Backport{},
+		},
+		{
+			name:    "cherry-pick",
+			message: "fix: typo\n\nbody\n\n(cherry picked from commit 0123456789abcdef0123456789abcdef01234567)",
+			want:    []Backport{{Hash: "0123456789abcdef0123456789abcdef01234567", Source: "cherry-pick"}},
+		},
+		{
+			name:    "trailers",
+			message: "fix: typo\n\nBackport-of: abc1234 (fix: typo)\nupstream-commit: def5678",
+			want: []Backport{
+				{Hash: "abc1234", Source: "Backport-of"},
+				{Hash: "def5678", Source: "Upstream-commit"},
+			},
+		},
+		{
+			name:    "cherry-pick in prose",
+			message: "fix: typo\n\nsee (cherry picked from commit abc1234) in the docs",
+			want:    []Backport{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.message).ParseBackports())
+		})
+	}
+}
+
+func TestGroupBackports(t *testing.T) {
+	original := &Commit{Hash: "aaaaaaa1111", Release: "1.5.0", Message: Parse("fix: typo")}
+	backport := &Commit{Hash: "bbbbbbb2222", Release: "1.4.2", Message: Parse("fix: typo\n\n(cherry picked from commit aaaaaaa1111)")}
+	backportOfBackport := &Commit{Hash: "ccccccc3333", Release: "1.3.9", Message: Parse("fix: typo\n\nBackport-of: bbbbbbb")}
+	other := &Commit{Hash: "ddddddd4444", Release: "1.5.0", Message: Parse("feat: search")}
+	orphan1 := &Commit{Hash: "eeeeeee5555", Release: "1.4.2", Message: Parse("fix: leak\n\nUpstream-commit: 9999999")}
+	orphan2 := &Commit{Hash: "fffffff6666", Release: "1.3.9", Message: Parse("fix: leak\n\n(cherry picked from commit 9999999)")}
+
+	groups := GroupBackports([]*Commit{backport, other, backportOfBackport, original, orphan1, orphan2})
+
+	assert.Equal(t, []BackportGroup{
+		{Commit: original, Backports: []*Commit{backport, backportOfBackport}},
+		{Commit: other, Backports: []*Commit{}},
+		{Commit: orphan1, Backports: []*Commit{orphan2}},
+	}, groups)
+	assert.Equal(t, []string{"1.4.2", "1.3.9"}, groups[0].Releases())
+	assert.Equal(t, []string{}, groups[1].Releases())
+	assert.Equal(t, []string{"1.3.9"}, groups[2].Releases())
+}
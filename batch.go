@@ -0,0 +1,154 @@
+package conventionalcommitparser
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// parseUpFront parses a message along with its representation, see Message.Parsed
+func parseUpFront(message string) *Message {
+	msg := Parse(message)
+	msg.Parsed()
+
+	return msg
+}
+
+func defaultWorkers(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+
+	return workers
+}
+
+// ParseAll parses the messages with the given number of goroutines,
+// GOMAXPROCS when workers is not positive. The results are in the order of
+// the messages. The representation of each message is computed up front, see
+// Message.Parsed. It returns the error of the context when it is done
+// before all the messages are parsed.
+//
+// Parse, ParseWith, ParseAll and ParseStream are safe for concurrent use.
+// A Message can be read by many goroutines, its methods included, as long
+// as none of them modifies it.
+func ParseAll(ctx context.Context, messages []string, workers int) ([]*Message, error) {
+	results := make([]*Message, len(messages))
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for n := defaultWorkers(workers); n > 0; n-- {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				results[i] = parseUpFront(messages[i])
+			}
+		}()
+	}
+
+	err := error(nil)
+
+loop:
+	for i := range messages {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break loop
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ParseStream parses the messages received from the channel with the given
+// number of goroutines, like ParseAll, and sends the results in the order of
+// the messages. The returned channel is closed once the messages channel is
+// closed and drained, or when the context is done; callers tell the two
+// apart with the error of the context. At most 2*workers messages are held
+// in memory at once, so it suits histories too large for ParseAll.
+func ParseStream(ctx context.Context, messages <-chan string, workers int) <-chan *Message {
+	workers = defaultWorkers(workers)
+	results := make(chan *Message)
+
+	type job struct {
+		message string
+		result  chan *Message
+	}
+
+	jobs := make(chan job)
+	// the pending results, in the order of the messages
+	pending := make(chan chan *Message, workers)
+
+	for n := workers; n > 0; n-- {
+		go func() {
+			for j := range jobs {
+				j.result <- parseUpFront(j.message)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+
+		for {
+			var message string
+			var ok bool
+
+			select {
+			case message, ok = <-messages:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			j := job{message: message, result: make(chan *Message, 1)}
+
+			select {
+			case pending <- j.result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(results)
+
+		for result := range pending {
+			var msg *Message
+
+			select {
+			case msg = <-result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case results <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results
+}
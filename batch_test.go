@@ -0,0 +1,133 @@
+package conventionalcommitparser
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func batchMessages(n int) []string {
+	r := rand.New(rand.NewSource(1))
+	messages := make([]string, n)
+
+	for i := range messages {
+		messages[i] = fmt.Sprintf("%d %s", i, randomMessage(r))
+	}
+
+	return messages
+}
+
+func TestParseAll(t *testing.T) {
+	messages := append(batchMessages(500), benchmarkMessage)
+
+	for _, workers := range []int{0, 1, 7} {
+		results, err := ParseAll(context.Background(), messages, workers)
+
+		assert.Nil(t, err)
+		if assert.Len(t, results, len(messages)) {
+			for i, msg := range results {
+				want := Parse(messages[i])
+				assert.Equal(t, want.ParseHeader(), msg.ParseHeader())
+				assert.Equal(t, want.ParseFooter(), msg.ParseFooter())
+				assert.Equal(t, want.Parsed().References, msg.Parsed().References)
+				assert.Equal(t, want.Trailers().Keys(), msg.Trailers().Keys())
+				assert.Equal(t, want.Footer, msg.Footer)
+			}
+		}
+	}
+
+	results, err := ParseAll(context.Background(), nil, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Message{}, results)
+}
+
+func TestParseAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := ParseAll(ctx, batchMessages(100), 2)
+	assert.Nil(t, results)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestParseStream(t *testing.T) {
+	messages := batchMessages(300)
+	input := make(chan string)
+
+	go func() {
+		defer close(input)
+
+		for _, message := range messages {
+			input <- message
+		}
+	}()
+
+	i := 0
+	for msg := range ParseStream(context.Background(), input, 4) {
+		assert.Equal(t, Parse(messages[i]).ParseHeader(), msg.ParseHeader())
+		i++
+	}
+
+	assert.Equal(t, len(messages), i)
+}
+
+func TestParseStreamCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	input := make(chan string)
+
+	go func() {
+		for {
+			select {
+			case input <- "fix: x":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := ParseStream(ctx, input, 3)
+	<-results
+	<-results
+	cancel()
+
+	for range results {
+	}
+
+	assert.Equal(t, context.Canceled, ctx.Err())
+}
+
+// TestMessageConcurrentReads is meant for -race
+func TestMessageConcurrentReads(t *testing.T) {
+	msg := Parse(benchmarkMessage)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.Equal(t, "api", msg.ParseHeader().Scope)
+			assert.Equal(t, []string{"#123", "#124"}, msg.GetCloses())
+			assert.True(t, msg.IsBreaking())
+			assert.Equal(t, "Z", msg.Trailers().Get("reviewed-by"))
+			msg.BodyBlocks()
+		}()
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	messages := batchMessages(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ParseAll(context.Background(), messages, 0)
+	}
+}
@@ -0,0 +1,73 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bump is the semver impact of a change
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+var bumpNames = []string{"none", "patch", "minor", "major"}
+
+func (b Bump) String() string {
+	if b < BumpNone || b > BumpMajor {
+		return fmt.Sprintf("Bump(%d)", int(b))
+	}
+
+	return bumpNames[b]
+}
+
+func (b Bump) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *Bump) UnmarshalText(text []byte) error {
+	for i, name := range bumpNames {
+		if strings.EqualFold(name, string(text)) {
+			*b = Bump(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid bump %q", string(text))
+}
+
+// IsBreaking reports whether the header is marked with `!`
+// or a BREAKING CHANGE footer is present
+func (m *Message) IsBreaking() bool {
+	return len(m.Parsed().BreakingChanges) != 0
+}
+
+// Bump returns the semver impact of the message
+func (r *TypeRegistry) Bump(m *Message) Bump {
+	if m.IsBreaking() {
+		return BumpMajor
+	}
+
+	if t := r.Lookup(m.ParseHeader().Type); t != nil {
+		return t.Bump
+	}
+
+	return BumpNone
+}
+
+// BumpCommits returns the highest semver impact of the commits
+func (r *TypeRegistry) BumpCommits(commits []*Commit) Bump {
+	bump := BumpNone
+
+	for _, c := range commits {
+		if b := r.Bump(c.Message); b > bump {
+			bump = b
+		}
+	}
+
+	return bump
+}
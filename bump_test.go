@@ -0,0 +1,55 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBumpText(t *testing.T) {
+	for _, b := range []Bump{BumpNone, BumpPatch, BumpMinor, BumpMajor} {
+		text, err := b.MarshalText()
+		assert.NoError(t, err)
+
+		var got Bump
+		assert.NoError(t, got.UnmarshalText(text))
+		assert.Equal(t, b, got)
+	}
+
+	var b Bump
+	assert.Error(t, b.UnmarshalText([]byte("huge")))
+	assert.Equal(t, "Bump(7)", Bump(7).String())
+}
+
+func TestTypeRegistryBump(t *testing.T) {
+	tests := []struct {
+		message string
+		want    Bump
+	}{
+		{message: "feat: subject", want: BumpMinor},
+		{message: "feature: subject", want: BumpMinor},
+		{message: "fix: subject", want: BumpPatch},
+		{message: "docs: subject", want: BumpNone},
+		{message: "common commit", want: BumpNone},
+		{message: "docs!: subject", want: BumpMajor},
+		{message: "fix: subject\n\nBREAKING CHANGE: removed", want: BumpMajor},
+		{message: "fix: subject\n\nBREAKING-CHANGE: removed", want: BumpMajor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, DefaultTypeRegistry.Bump(Parse(tt.message)))
+		})
+	}
+}
+
+func TestTypeRegistryBumpCommits(t *testing.T) {
+	commits := []*Commit{
+		{Message: Parse("docs: subject")},
+		{Message: Parse("fix: subject")},
+		{Message: Parse("feat: subject")},
+		{Message: Parse("chore: subject")},
+	}
+
+	assert.Equal(t, BumpMinor, DefaultTypeRegistry.BumpCommits(commits))
+	assert.Equal(t, BumpNone, DefaultTypeRegistry.BumpCommits(nil))
+}
@@ -0,0 +1,300 @@
+package conventionalcommitparser
+
+import (
+	"sort"
+	"strings"
+)
+
+// MergeMode decides what BuildChangelog does with merge commits
+type MergeMode int
+
+const (
+	// MergesSkip leaves merge commits out
+	MergesSkip MergeMode = iota
+	// MergesInclude lists merge commits in a section of their own
+	MergesInclude
+	// MergesExpand parses the body of merge commits, the title of the
+	// pull request, as the message of the commit
+	MergesExpand
+)
+
+// ChangelogOptions configure BuildChangelog
+type ChangelogOptions struct {
+	// Types give the sections and their order, DefaultTypeRegistry when nil
+	Types *TypeRegistry
+	// Scopes order the entries of each section, commit order when nil
+	Scopes *ScopeRegistry
+	Merges MergeMode
+	// MergePatterns recognize merge commits, DefaultMergePatterns when nil
+	MergePatterns []MergePattern
+	// ExpandSquashes lists the squashed commits of squash merges, see ExpandSquashes
+	ExpandSquashes bool
+	// CancelReverts leaves out reverted commits and their reverts, see CancelReverts
+	CancelReverts bool
+	// DedupeBackports lists a commit once along with the releases of its backports, see GroupBackports
+	DedupeBackports bool
+	// IncludeAutosquash lists `fixup!`, `squash!` and `amend!` commits
+	// with the type of their target, they are left out by default
+	IncludeAutosquash bool
+	// Emoji prefixes the section titles with the emoji of their type
+	Emoji bool
+	// InferTypes lists the commits without a registered type under the type
+	// guessed by TypeRegistry.Infer, when its confidence reaches InferTypes.
+	// Zero leaves them out.
+	InferTypes float64
+}
+
+type ChangelogEntry struct {
+	Commit *Commit
+	Header Header
+	// Description is the subject, or the BREAKING CHANGE footer in the breaking section
+	Description string
+	// Merge is set for included and expanded merge commits
+	Merge *Merge
+	// AlsoReleasedIn are the releases of the backports of the commit
+	AlsoReleasedIn []string
+}
+
+type ChangelogSection struct {
+	// Type is empty for the breaking changes section
+	Type    string
+	Title   string
+	Entries []ChangelogEntry
+}
+
+const (
+	breakingChangesTitle = "BREAKING CHANGES"
+	breakingChangesEmoji = "💥"
+	mergesTitle          = "Merges"
+)
+
+func (o ChangelogOptions) types() *TypeRegistry {
+	if o.Types == nil {
+		return DefaultTypeRegistry
+	}
+
+	return o.Types
+}
+
+func (o ChangelogOptions) mergePatterns() []MergePattern {
+	if o.MergePatterns == nil {
+		return DefaultMergePatterns
+	}
+
+	return o.MergePatterns
+}
+
+// history applies ExpandSquashes and CancelReverts to the commits, the
+// releases bump the version from the same commits
+func (o ChangelogOptions) history(commits []*Commit) []*Commit {
+	if o.ExpandSquashes {
+		commits = ExpandSquashes(commits)
+	}
+
+	if o.CancelReverts {
+		commits = CancelReverts(commits)
+	}
+
+	return commits
+}
+
+func entryScope(e ChangelogEntry) string {
+	if scopes := e.Header.HierarchicalScopes(); len(scopes) != 0 {
+		return scopes[0].String()
+	}
+
+	return ""
+}
+
+// entryScopeTitle returns the scopes of the entry for display, `payments > stripe, web`
+func entryScopeTitle(e ChangelogEntry) string {
+	titles := make([]string, 0)
+
+	for _, scope := range e.Header.HierarchicalScopes() {
+		titles = append(titles, scope.Title())
+	}
+
+	return strings.Join(titles, ", ")
+}
+
+func breakingDescription(m *Message, header Header) string {
+	if footer := m.GetFooterByField("BREAKING CHANGE", "BREAKING-CHANGE"); footer != nil && footer.Title != "" {
+		return footer.Title
+	}
+
+	return header.Subject
+}
+
+// BuildChangelog groups the commits into sections following the type registry.
+// Hidden and unknown types are left out, breaking changes get a section of their own
+// and merge commits are handled following ChangelogOptions.Merges.
+func BuildChangelog(commits []*Commit, opts ChangelogOptions) []ChangelogSection {
+	types := opts.types()
+	commits = opts.history(commits)
+
+	alsoReleasedIn := make(map[*Commit][]string)
+
+	if opts.DedupeBackports {
+		groups := GroupBackports(commits)
+		commits = make([]*Commit, 0, len(groups))
+
+		for _, g := range groups {
+			commits = append(commits, g.Commit)
+			alsoReleasedIn[g.Commit] = g.Releases()
+		}
+	}
+
+	breaking := ChangelogSection{Title: breakingChangesTitle}
+
+	if opts.Emoji {
+		breaking.Title = breakingChangesEmoji + " " + breakingChangesTitle
+	}
+	merges := ChangelogSection{Type: "merge", Title: mergesTitle}
+	sections := make([]ChangelogSection, len(types.Types))
+
+	for i, t := range types.Types {
+		sections[i] = ChangelogSection{Type: t.Name, Title: t.Section}
+
+		if opts.Emoji && t.Emoji != "" {
+			sections[i].Title = t.Emoji + " " + t.Section
+		}
+	}
+
+	for _, c := range commits {
+		msg := c.Message
+		autosquash := msg.ParseAutosquash()
+
+		if autosquash != nil && !opts.IncludeAutosquash {
+			continue
+		}
+
+		merge := ParseMerge(msg, opts.mergePatterns())
+
+		if merge != nil {
+			switch opts.Merges {
+			case MergesInclude:
+				merges.Entries = append(merges.Entries, ChangelogEntry{
+					Commit:         c,
+					Header:         msg.ParseHeader(),
+					Description:    msg.Header,
+					Merge:          merge,
+					AlsoReleasedIn: alsoReleasedIn[c],
+				})
+				continue
+			case MergesExpand:
+				msg = parseWith(msg.afterHeader(), msg.parser)
+			default:
+				continue
+			}
+		}
+
+		header := msg.ParseHeader()
+
+		// included autosquash commits are listed with the commit they target
+		if autosquash != nil {
+			header = autosquash.Target
+		}
+
+		if msg.IsBreaking() {
+			breaking.Entries = append(breaking.Entries, ChangelogEntry{
+				Commit:         c,
+				Header:         header,
+				Description:    breakingDescription(msg, header),
+				Merge:          merge,
+				AlsoReleasedIn: alsoReleasedIn[c],
+			})
+		}
+
+		i := types.Index(header.Type)
+		description := header.Subject
+
+		if i == -1 && opts.InferTypes > 0 {
+			if inference := types.Infer(msg, c.Paths); inference != nil && inference.Confidence >= opts.InferTypes {
+				i = types.Index(inference.Type)
+
+				// the subject of `Update docs: x` is not the whole story
+				if header.Type != "" {
+					description = strings.TrimSpace(msg.Header)
+				}
+			}
+		}
+
+		if i == -1 || types.Types[i].Hidden {
+			continue
+		}
+
+		sections[i].Entries = append(sections[i].Entries, ChangelogEntry{
+			Commit:         c,
+			Header:         header,
+			Description:    description,
+			Merge:          merge,
+			AlsoReleasedIn: alsoReleasedIn[c],
+		})
+	}
+
+	result := make([]ChangelogSection, 0)
+	sections = append([]ChangelogSection{breaking}, sections...)
+
+	for _, section := range append(sections, merges) {
+		if len(section.Entries) == 0 {
+			continue
+		}
+
+		if opts.Scopes != nil {
+			sort.SliceStable(section.Entries, func(i, j int) bool {
+				return opts.Scopes.Less(entryScope(section.Entries[i]), entryScope(section.Entries[j]))
+			})
+		}
+
+		result = append(result, section)
+	}
+
+	return result
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+
+	return hash
+}
+
+// RenderChangelog renders the sections as markdown
+func RenderChangelog(sections []ChangelogSection) string {
+	var b strings.Builder
+
+	for i, section := range sections {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+
+		b.WriteString("### " + section.Title + "\n\n")
+
+		for _, e := range section.Entries {
+			b.WriteString("* ")
+
+			if scope := entryScopeTitle(e); scope != "" {
+				b.WriteString("**" + scope + ":** ")
+			}
+
+			b.WriteString(e.Description)
+
+			if e.Merge != nil && e.Merge.Reference() != "" {
+				b.WriteString(" (" + e.Merge.Reference() + ")")
+			}
+
+			if e.Commit.Hash != "" {
+				b.WriteString(" (" + shortHash(e.Commit.Hash) + ")")
+			}
+
+			if len(e.AlsoReleasedIn) != 0 {
+				b.WriteString(" (also released in " + strings.Join(e.AlsoReleasedIn, ", ") + ")")
+			}
+
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,207 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildChangelog(t *testing.T) {
+	registry, err := ParseScopeRegistry([]byte(scopeRegistryYAML))
+	assert.NoError(t, err)
+
+	commits := []*Commit{
+		{Hash: "1111111aaaa", Message: Parse("feat(payments): add refunds")},
+		{Hash: "2222222bbbb", Message: Parse("fix: typo")},
+		{Hash: "3333333cccc", Message: Parse("feature(api): add search")},
+		{Hash: "4444444dddd", Message: Parse("docs: readme")},
+		{Hash: "5555555eeee", Message: Parse("feat(api)!: drop v1\n\nBREAKING CHANGE: v1 endpoints are removed")},
+		{Hash: "6666666ffff", Message: Parse("common commit")},
+	}
+
+	sections := BuildChangelog(commits, ChangelogOptions{Scopes: registry})
+
+	assert.Equal(t, []ChangelogSection{
+		{
+			Title: "BREAKING CHANGES",
+			Entries: []ChangelogEntry{
+				{Commit: commits[4], Header: commits[4].Message.ParseHeader(), Description: "v1 endpoints are removed"},
+			},
+		},
+		{
+			Type:  "feat",
+			Title: "Features",
+			Entries: []ChangelogEntry{
+				{Commit: commits[2], Header: commits[2].Message.ParseHeader(), Description: "add search"},
+				{Commit: commits[4], Header: commits[4].Message.ParseHeader(), Description: "drop v1"},
+				{Commit: commits[0], Header: commits[0].Message.ParseHeader(), Description: "add refunds"},
+			},
+		},
+		{
+			Type:  "fix",
+			Title: "Bug Fixes",
+			Entries: []ChangelogEntry{
+				{Commit: commits[1], Header: commits[1].Message.ParseHeader(), Description: "typo"},
+			},
+		},
+	}, sections)
+
+	assert.Equal(t, `### BREAKING CHANGES
+
+* **api:** v1 endpoints are removed (5555555)
+
+### Features
+
+* **api:** add search (3333333)
+* **api:** drop v1 (5555555)
+* **payments:** add refunds (1111111)
+
+### Bug Fixes
+
+* typo (2222222)
+`, RenderChangelog(sections))
+}
+
+func TestBuildChangelogCommitOrder(t *testing.T) {
+	commits := []*Commit{
+		{Message: Parse("feat(web): b")},
+		{Message: Parse("feat(api): a")},
+	}
+
+	sections := BuildChangelog(commits, ChangelogOptions{})
+
+	assert.Equal(t, "### Features\n\n* **web:** b\n* **api:** a\n", RenderChangelog(sections))
+	assert.Equal(t, []ChangelogSection{}, BuildChangelog(nil, ChangelogOptions{}))
+}
+
+func TestRenderChangelogNestedScopes(t *testing.T) {
+	commits := []*Commit{
+		{Message: Parse("feat(payments/stripe): add refunds")},
+		{Message: Parse("feat(payments/stripe, web): add receipts")},
+	}
+
+	assert.Equal(t, "### Features\n\n* **payments > stripe:** add refunds\n* **payments > stripe, web:** add receipts\n",
+		RenderChangelog(BuildChangelog(commits, ChangelogOptions{})))
+}
+
+func TestBuildChangelogMerges(t *testing.T) {
+	commits := []*Commit{
+		{Hash: "1111111", Message: Parse("Merge pull request #42 from org/feature-x\n\nfeat(api): add search")},
+		{Hash: "2222222", Message: Parse("Merge branch 'main' into dev")},
+		{Hash: "3333333", Message: Parse("fix: typo")},
+	}
+
+	tests := []struct {
+		name   string
+		merges MergeMode
+		want   string
+	}{
+		{
+			name:   "skip",
+			merges: MergesSkip,
+			want:   "### Bug Fixes\n\n* typo (3333333)\n",
+		},
+		{
+			name:   "include",
+			merges: MergesInclude,
+			want: "### Bug Fixes\n\n* typo (3333333)\n\n" +
+				"### Merges\n\n* Merge pull request #42 from org/feature-x (#42) (1111111)\n* Merge branch 'main' into dev (2222222)\n",
+		},
+		{
+			name:   "expand",
+			merges: MergesExpand,
+			want:   "### Features\n\n* **api:** add search (#42) (1111111)\n\n### Bug Fixes\n\n* typo (3333333)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RenderChangelog(BuildChangelog(commits, ChangelogOptions{Merges: tt.merges})))
+		})
+	}
+}
+
+func TestBuildChangelogExpandSquashes(t *testing.T) {
+	commits := []*Commit{
+		{Hash: "1111111", Message: Parse("feat(api): add search (#812)\n\n* feat(api): add search\n\n* fix: typo")},
+	}
+
+	assert.Equal(t, "### Features\n\n* **api:** add search (#812) (1111111)\n",
+		RenderChangelog(BuildChangelog(commits, ChangelogOptions{})))
+	assert.Equal(t, "### Features\n\n* **api:** add search (#812) (1111111)\n\n### Bug Fixes\n\n* typo (1111111)\n",
+		RenderChangelog(BuildChangelog(commits, ChangelogOptions{ExpandSquashes: true})))
+
+	commits = []*Commit{
+		{Hash: "2222222", Message: Parse("fix(api): handle empty queries\n\nfeat: flag was ignored by the handler,\nso every query failed\n\nSigned-off-by: A")},
+	}
+
+	assert.Equal(t, "### Bug Fixes\n\n* **api:** handle empty queries (2222222)\n",
+		RenderChangelog(BuildChangelog(commits, ChangelogOptions{ExpandSquashes: true})))
+}
+
+func TestBuildChangelogCancelReverts(t *testing.T) {
+	commits := []*Commit{
+		{Hash: "3333333", Message: Parse("Revert \"feat: x\"\n\nThis reverts commit 1111111.")},
+		{Hash: "2222222", Message: Parse("fix: y")},
+		{Hash: "1111111", Message: Parse("feat: x")},
+	}
+
+	assert.Equal(t, "### Bug Fixes\n\n* y (2222222)\n", RenderChangelog(BuildChangelog(commits, ChangelogOptions{CancelReverts: true})))
+	assert.Equal(t, BumpPatch, DefaultTypeRegistry.BumpCommits(CancelReverts(commits)))
+}
+
+func TestBuildChangelogDedupeBackports(t *testing.T) {
+	commits := []*Commit{
+		{Hash: "aaaaaaa1111", Release: "1.5.0", Message: Parse("fix: typo")},
+		{Hash: "bbbbbbb2222", Release: "1.4.2", Message: Parse("fix: typo\n\n(cherry picked from commit aaaaaaa1111)")},
+		{Hash: "ccccccc3333", Release: "1.3.9", Message: Parse("fix: typo\n\n(cherry picked from commit aaaaaaa1111)")},
+	}
+
+	assert.Equal(t, "### Bug Fixes\n\n* typo (aaaaaaa) (also released in 1.4.2, 1.3.9)\n",
+		RenderChangelog(BuildChangelog(commits, ChangelogOptions{DedupeBackports: true})))
+}
+
+func TestBuildChangelogAutosquash(t *testing.T) {
+	commits := []*Commit{
+		{Hash: "1111111", Message: Parse("feat: add search")},
+		{Hash: "2222222", Message: Parse("fixup! feat: add search")},
+	}
+
+	assert.Len(t, BuildChangelog(commits, ChangelogOptions{})[0].Entries, 1)
+	assert.Len(t, BuildChangelog(commits, ChangelogOptions{IncludeAutosquash: true})[0].Entries, 2)
+}
+
+func TestBuildChangelogInferTypes(t *testing.T) {
+	commits := []*Commit{
+		{Hash: "1111111", Message: Parse("Fix login redirect")},
+		{Hash: "2222222", Message: Parse("Update docs: install")},
+		{Hash: "3333333", Message: Parse("Improve search"), Paths: []string{"search.go"}},
+		{Hash: "4444444", Message: Parse("Implement search")},
+	}
+
+	assert.Equal(t, []ChangelogSection{}, BuildChangelog(commits, ChangelogOptions{}))
+
+	assert.Equal(t, []ChangelogSection{
+		{
+			Type:  "feat",
+			Title: "Features",
+			Entries: []ChangelogEntry{
+				{Commit: commits[3], Header: commits[3].Message.ParseHeader(), Description: "Implement search"},
+			},
+		},
+		{
+			Type:  "fix",
+			Title: "Bug Fixes",
+			Entries: []ChangelogEntry{
+				{Commit: commits[0], Header: commits[0].Message.ParseHeader(), Description: "Fix login redirect"},
+			},
+		},
+	}, BuildChangelog(commits, ChangelogOptions{InferTypes: 0.5}))
+
+	// the whole header describes the commits with an unregistered type
+	types := &TypeRegistry{Types: []TypeDefinition{{Name: "docs", Section: "Documentation"}}}
+	sections := BuildChangelog(commits, ChangelogOptions{Types: types, InferTypes: 0.5})
+
+	if assert.Len(t, sections, 1) {
+		assert.Equal(t, "Update docs: install", sections[0].Entries[0].Description)
+	}
+}
@@ -0,0 +1,11 @@
+package conventionalcommitparser
+
+// Commit is a parsed message along with what is known about the commit
+type Commit struct {
+	Hash    string
+	Message *Message
+	// Paths are the files changed by the commit, when known
+	Paths []string
+	// Release is the version containing the commit, when known
+	Release string
+}
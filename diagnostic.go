@@ -0,0 +1,149 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DiagnosticCode identifies a problem found by Diagnose
+type DiagnosticCode string
+
+const (
+	// DiagnosticInvisibleCharacters is a byte order mark or a zero width
+	// character before the header, they are removed
+	DiagnosticInvisibleCharacters DiagnosticCode = "invisible-characters"
+	// DiagnosticLeadingBlankLines are blank lines before the header, they are removed
+	DiagnosticLeadingBlankLines DiagnosticCode = "leading-blank-lines"
+	// DiagnosticWrappedHeader is a header wrapped by an editor,
+	// the second line is joined to the subject
+	DiagnosticWrappedHeader DiagnosticCode = "wrapped-header"
+	// DiagnosticMissingBlankLine is a body following the header without
+	// a blank line, one is inserted
+	DiagnosticMissingBlankLine DiagnosticCode = "missing-blank-line"
+)
+
+// Diagnostic is a problem of the message layout
+type Diagnostic struct {
+	Code DiagnosticCode
+	// Line of the original message, from 1
+	Line    int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("line %d: %s: %s", d.Line, d.Code, d.Message)
+}
+
+// wrapHeaderWidth is the width from which a header followed by a single
+// line is considered wrapped, whatever the case of the line
+const wrapHeaderWidth = 50
+
+func isInvisible(r rune) bool {
+	switch r {
+	case 0xFEFF, 0x200B, 0x200C, 0x200D, 0x2060:
+		return true
+	}
+
+	return false
+}
+
+func isBlankOrInvisible(line string) bool {
+	return strings.TrimFunc(line, func(r rune) bool {
+		return unicode.IsSpace(r) || isInvisible(r)
+	}) == ""
+}
+
+// isWrappedHeader reports whether the line following the header without
+// a blank line is the end of the subject. The line must be alone in its
+// paragraph, the header must not end a sentence and the line must start
+// in lower case unless the header is as long as editors wrap it.
+func isWrappedHeader(header string, line string, next string) bool {
+	if !isEmptyLine(next) {
+		return false
+	}
+
+	header = strings.TrimRightFunc(header, unicode.IsSpace)
+	if last, _ := utf8.DecodeLastRuneInString(header); strings.ContainsRune(`.!?:;"'`, last) {
+		return false
+	}
+
+	first, _ := utf8.DecodeRuneInString(strings.TrimSpace(line))
+
+	return unicode.IsLower(first) || DisplayWidth(header) >= wrapHeaderWidth
+}
+
+// RecoverMessage fixes the layout problems of the message and returns the
+// fixed message along with a diagnostic for each of them
+func RecoverMessage(message string) (string, []Diagnostic) {
+	diagnostics := make([]Diagnostic, 0)
+	lines := splitToLines(message)
+	skipped := 0
+	invisible := false
+
+	for skipped < len(lines)-1 && isBlankOrInvisible(lines[skipped]) {
+		invisible = invisible || strings.IndexFunc(lines[skipped], isInvisible) != -1
+		skipped++
+	}
+
+	if header := strings.TrimLeftFunc(lines[skipped], isInvisible); header != lines[skipped] {
+		lines[skipped] = header
+		invisible = true
+	}
+
+	if invisible {
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:    DiagnosticInvisibleCharacters,
+			Line:    1,
+			Message: "the message starts with invisible characters, such as a byte order mark",
+		})
+	}
+
+	if skipped != 0 {
+		message := "a blank line precedes the header"
+		if skipped > 1 {
+			message = fmt.Sprintf("%d blank lines precede the header", skipped)
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:    DiagnosticLeadingBlankLines,
+			Line:    1,
+			Message: message,
+		})
+		lines = lines[skipped:]
+	}
+
+	if len(lines) > 1 && !isEmptyLine(lines[1]) {
+		next := ""
+		if len(lines) > 2 {
+			next = lines[2]
+		}
+
+		if isWrappedHeader(lines[0], lines[1], next) {
+			diagnostics = append(diagnostics, Diagnostic{
+				Code:    DiagnosticWrappedHeader,
+				Line:    skipped + 2,
+				Message: "the header is wrapped over two lines",
+			})
+			lines[0] = strings.TrimRightFunc(lines[0], unicode.IsSpace) + " " + strings.TrimSpace(lines[1])
+			lines = append(lines[:1], lines[2:]...)
+		} else {
+			diagnostics = append(diagnostics, Diagnostic{
+				Code:    DiagnosticMissingBlankLine,
+				Line:    skipped + 2,
+				Message: "the body must be separated from the header by a blank line",
+			})
+			lines = append(lines[:1], append([]string{""}, lines[1:]...)...)
+		}
+	}
+
+	return strings.Join(lines, "\n"), diagnostics
+}
+
+// Diagnose returns the layout problems of the message, see RecoverMessage
+func Diagnose(message string) []Diagnostic {
+	_, diagnostics := RecoverMessage(message)
+
+	return diagnostics
+}
@@ -0,0 +1,96 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		message         string
+		want            string
+		wantDiagnostics []Diagnostic
+	}{
+		{
+			name:            "valid",
+			message:         "feat: x\n\nbody",
+			want:            "feat: x\n\nbody",
+			wantDiagnostics: []Diagnostic{},
+		},
+		{
+			name:    "byte order mark",
+			message: "\ufefffeat: x",
+			want:    "feat: x",
+			wantDiagnostics: []Diagnostic{
+				{Code: DiagnosticInvisibleCharacters, Line: 1, Message: "the message starts with invisible characters, such as a byte order mark"},
+			},
+		},
+		{
+			name:    "leading blank lines",
+			message: "\n\u200b \n\r\nfeat: x\n\nbody",
+			want:    "feat: x\n\nbody",
+			wantDiagnostics: []Diagnostic{
+				{Code: DiagnosticInvisibleCharacters, Line: 1, Message: "the message starts with invisible characters, such as a byte order mark"},
+				{Code: DiagnosticLeadingBlankLines, Line: 1, Message: "3 blank lines precede the header"},
+			},
+		},
+		{
+			name:    "wrapped in lower case",
+			message: "feat: x\nmore detail\n",
+			want:    "feat: x more detail\n",
+			wantDiagnostics: []Diagnostic{
+				{Code: DiagnosticWrappedHeader, Line: 2, Message: "the header is wrapped over two lines"},
+			},
+		},
+		{
+			name:    "wrapped long header",
+			message: "\nfeat(api): support the search of the archived projects by\nOwner\n\nbody",
+			want:    "feat(api): support the search of the archived projects by Owner\n\nbody",
+			wantDiagnostics: []Diagnostic{
+				{Code: DiagnosticLeadingBlankLines, Line: 1, Message: "a blank line precedes the header"},
+				{Code: DiagnosticWrappedHeader, Line: 3, Message: "the header is wrapped over two lines"},
+			},
+		},
+		{
+			name:    "missing blank line",
+			message: "Revert \"feat: x\"\nThis reverts commit bf08694.",
+			want:    "Revert \"feat: x\"\n\nThis reverts commit bf08694.",
+			wantDiagnostics: []Diagnostic{
+				{Code: DiagnosticMissingBlankLine, Line: 2, Message: "the body must be separated from the header by a blank line"},
+			},
+		},
+		{
+			name:    "missing blank line before a paragraph",
+			message: "feat: x\nthe first line\nthe second line",
+			want:    "feat: x\n\nthe first line\nthe second line",
+			wantDiagnostics: []Diagnostic{
+				{Code: DiagnosticMissingBlankLine, Line: 2, Message: "the body must be separated from the header by a blank line"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diagnostics := RecoverMessage(tt.message)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantDiagnostics, diagnostics)
+			assert.Equal(t, tt.wantDiagnostics, Diagnose(tt.message))
+		})
+	}
+}
+
+func TestParseRecover(t *testing.T) {
+	msg := ParseWith("\ufeff\nfeat: x\nmore detail\n\nRefs: #1", ParseOptions{Recover: true})
+
+	assert.Equal(t, "feat: x more detail", msg.Header)
+	assert.Equal(t, "", msg.Body)
+	assert.Equal(t, []string{"Refs: #1"}, msg.Footer)
+
+	msg = ParseWith("fix: typo\nRefs: #1", ParseOptions{Recover: true})
+
+	assert.Equal(t, "", msg.Body)
+	assert.Equal(t, []string{"Refs: #1"}, msg.Footer)
+	assert.Equal(t, "line 2: missing-blank-line: the body must be separated from the header by a blank line", Diagnose("fix: typo\nRefs: #1")[0].String())
+}
@@ -1,7 +1,6 @@
 package conventionalcommitparser
 
 import (
-	"regexp"
 	"strings"
 )
 
@@ -11,28 +10,12 @@ type Footer struct {
 	Content string
 }
 
-var (
-	footerTagPattern            = regexp.MustCompile(`(?i)^([a-z]+(-[a-z]+)*):\s?(.*)$`)
-	footerHashPattern           = regexp.MustCompile(`^(?i)^([\w\-]+)\s+(#.*)`)
-	footerBreakingChangePattern = regexp.MustCompile(`^(BREAKING\sCHANGE):\s*(.*)$`)
-)
-
 func paseFooterParagraph(txt string) Footer {
 	footer := Footer{}
 
-	tagMatcher := footerTagPattern.FindStringSubmatch(txt)
-	breakingChangeMatcher := footerBreakingChangePattern.FindStringSubmatch(txt)
-	hashTagMatcher := footerHashPattern.FindStringSubmatch(txt)
-
-	if len(breakingChangeMatcher) != 0 {
-		footer.Tag = strings.TrimSpace(breakingChangeMatcher[1])
-		footer.Title = strings.TrimSpace(breakingChangeMatcher[2])
-	} else if len(tagMatcher) != 0 {
-		footer.Tag = strings.TrimSpace(tagMatcher[1])
-		footer.Title = strings.TrimSpace(tagMatcher[3])
-	} else if len(hashTagMatcher) != 0 {
-		footer.Tag = strings.TrimSpace(hashTagMatcher[1])
-		footer.Title = strings.TrimSpace(hashTagMatcher[2])
+	if kind, token, value := scanFooter(txt); kind != footerNone {
+		footer.Tag = strings.TrimSpace(token)
+		footer.Title = strings.TrimSpace(value)
 	} else {
 		footer.Tag = ""
 		footer.Title = txt
@@ -42,39 +25,21 @@ func paseFooterParagraph(txt string) Footer {
 }
 
 func isFooterParagraph(txt string) bool {
-	if footerBreakingChangePattern.MatchString(txt) {
-		return true
-	}
-
-	if footerTagPattern.MatchString(txt) {
-		return true
-	}
+	kind, _, _ := scanFooter(txt)
 
-	if footerHashPattern.MatchString(txt) {
-		return true
-	}
-
-	return false
+	return kind != footerNone
 }
 
 func parseFooter(txt string) Footer {
-	lines := splitToLines(txt)
-
-	footer := Footer{}
-
-	contents := make([]string, 0)
+	txt = strings.ReplaceAll(txt, "\r\n", "\n")
+	line, contents := txt, ""
 
-lineLoop:
-	for index, line := range lines {
-		if index == 0 {
-			footer = paseFooterParagraph(line)
-			continue lineLoop
-		} else {
-			contents = append(contents, line)
-		}
+	if i := strings.IndexByte(txt, '\n'); i != -1 {
+		line, contents = txt[:i], txt[i+1:]
 	}
 
-	footer.Content = strings.TrimSpace(strings.Join(contents, "\n"))
+	footer := paseFooterParagraph(line)
+	footer.Content = strings.TrimSpace(contents)
 
 	return footer
 }
@@ -0,0 +1,72 @@
+//go:build go1.18
+// +build go1.18
+
+package conventionalcommitparser
+
+import (
+	"testing"
+	"time"
+)
+
+// fuzzTimeout bounds the time of a single input, the parsing is linear so
+// even the largest inputs of the fuzzer take a fraction of it
+const fuzzTimeout = time.Second
+
+func fuzzSeeds(f *testing.F) {
+	f.Add(benchmarkMessage)
+
+	for _, txt := range scannerHeaders {
+		f.Add(txt)
+	}
+
+	for _, txt := range scannerLines {
+		f.Add(txt)
+		f.Add("feat: x\n\n" + txt)
+	}
+}
+
+func checkDuration(t *testing.T, start time.Time, input string) {
+	if elapsed := time.Since(start); elapsed > fuzzTimeout {
+		t.Fatalf("%q took %s", input, elapsed)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	fuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, message string) {
+		start := time.Now()
+		msg := Parse(message)
+		msg.Parsed()
+		ParseWith(message, ParseOptions{LegacyFooters: true, Recover: true})
+		checkDuration(t, start, message)
+	})
+}
+
+func FuzzParseHeader(f *testing.F) {
+	fuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, txt string) {
+		start := time.Now()
+		got := parseHeader(txt)
+		checkDuration(t, start, txt)
+
+		if want := regexParseHeader(txt); got != want {
+			t.Fatalf("%q: got %+v, want %+v", txt, got, want)
+		}
+	})
+}
+
+func FuzzParseFooter(f *testing.F) {
+	fuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, txt string) {
+		start := time.Now()
+		got := parseFooter(txt)
+		checkDuration(t, start, txt)
+
+		if want := regexParseFooter(txt); got != want {
+			t.Fatalf("%q: got %+v, want %+v", txt, got, want)
+		}
+	})
+}
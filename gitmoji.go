@@ -0,0 +1,200 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Gitmoji maps an emoji to a conventional type
+// https://gitmoji.dev
+type Gitmoji struct {
+	Emoji string
+	Code  string
+	Type  string
+	// Breaking marks the header as important, as `!` does
+	Breaking bool
+}
+
+// Gitmojis is the table used to type headers starting with an emoji,
+// append to it to support custom emoji. It must not be modified while parsing.
+var Gitmojis = []Gitmoji{
+	{Emoji: "🎨", Code: ":art:", Type: "style"},
+	{Emoji: "⚡️", Code: ":zap:", Type: "perf"},
+	{Emoji: "🔥", Code: ":fire:", Type: "chore"},
+	{Emoji: "🐛", Code: ":bug:", Type: "fix"},
+	{Emoji: "🚑️", Code: ":ambulance:", Type: "fix"},
+	{Emoji: "✨", Code: ":sparkles:", Type: "feat"},
+	{Emoji: "📝", Code: ":memo:", Type: "docs"},
+	{Emoji: "🚀", Code: ":rocket:", Type: "chore"},
+	{Emoji: "💄", Code: ":lipstick:", Type: "style"},
+	{Emoji: "🎉", Code: ":tada:", Type: "chore"},
+	{Emoji: "✅", Code: ":white_check_mark:", Type: "test"},
+	{Emoji: "🔒️", Code: ":lock:", Type: "fix"},
+	{Emoji: "🔐", Code: ":closed_lock_with_key:", Type: "chore"},
+	{Emoji: "🔖", Code: ":bookmark:", Type: "chore"},
+	{Emoji: "🚨", Code: ":rotating_light:", Type: "style"},
+	{Emoji: "🚧", Code: ":construction:", Type: "chore"},
+	{Emoji: "💚", Code: ":green_heart:", Type: "ci"},
+	{Emoji: "⬇️", Code: ":arrow_down:", Type: "build"},
+	{Emoji: "⬆️", Code: ":arrow_up:", Type: "build"},
+	{Emoji: "📌", Code: ":pushpin:", Type: "build"},
+	{Emoji: "👷", Code: ":construction_worker:", Type: "ci"},
+	{Emoji: "📈", Code: ":chart_with_upwards_trend:", Type: "feat"},
+	{Emoji: "♻️", Code: ":recycle:", Type: "refactor"},
+	{Emoji: "➕", Code: ":heavy_plus_sign:", Type: "build"},
+	{Emoji: "➖", Code: ":heavy_minus_sign:", Type: "build"},
+	{Emoji: "🔧", Code: ":wrench:", Type: "chore"},
+	{Emoji: "🔨", Code: ":hammer:", Type: "chore"},
+	{Emoji: "🌐", Code: ":globe_with_meridians:", Type: "feat"},
+	{Emoji: "✏️", Code: ":pencil2:", Type: "fix"},
+	{Emoji: "⏪️", Code: ":rewind:", Type: "revert"},
+	{Emoji: "🔀", Code: ":twisted_rightwards_arrows:", Type: "chore"},
+	{Emoji: "📦️", Code: ":package:", Type: "build"},
+	{Emoji: "👽️", Code: ":alien:", Type: "fix"},
+	{Emoji: "🚚", Code: ":truck:", Type: "refactor"},
+	{Emoji: "📄", Code: ":page_facing_up:", Type: "chore"},
+	{Emoji: "💥", Code: ":boom:", Type: "feat", Breaking: true},
+	{Emoji: "🍱", Code: ":bento:", Type: "chore"},
+	{Emoji: "♿️", Code: ":wheelchair:", Type: "feat"},
+	{Emoji: "💡", Code: ":bulb:", Type: "docs"},
+	{Emoji: "🗃️", Code: ":card_file_box:", Type: "chore"},
+	{Emoji: "🔊", Code: ":loud_sound:", Type: "feat"},
+	{Emoji: "🔇", Code: ":mute:", Type: "refactor"},
+	{Emoji: "🏷️", Code: ":label:", Type: "refactor"},
+	{Emoji: "🗑️", Code: ":wastebasket:", Type: "refactor"},
+	{Emoji: "🧪", Code: ":test_tube:", Type: "test"},
+	{Emoji: "⚗️", Code: ":alembic:", Type: "chore"},
+	{Emoji: "🔍️", Code: ":mag:", Type: "feat"},
+	{Emoji: "🩹", Code: ":adhesive_bandage:", Type: "fix"},
+	{Emoji: "🧱", Code: ":bricks:", Type: "chore"},
+	{Emoji: "🙈", Code: ":see_no_evil:", Type: "chore"},
+	{Emoji: "📸", Code: ":camera_flash:", Type: "test"},
+	{Emoji: "🤡", Code: ":clown_face:", Type: "test"},
+	{Emoji: "🏗️", Code: ":building_construction:", Type: "refactor"},
+	{Emoji: "🥅", Code: ":goal_net:", Type: "fix"},
+	{Emoji: "💫", Code: ":dizzy:", Type: "feat"},
+	{Emoji: "⚰️", Code: ":coffin:", Type: "refactor"},
+	{Emoji: "🩺", Code: ":stethoscope:", Type: "feat"},
+	{Emoji: "🦺", Code: ":safety_vest:", Type: "feat"},
+}
+
+var emojiShortcodePattern = regexp.MustCompile(`^:[a-z0-9_+\-]+:`)
+
+// normalizeEmoji removes the variation selectors, `⚡️` and `⚡` are the same emoji
+func normalizeEmoji(emoji string) string {
+	return strings.ReplaceAll(emoji, "\ufe0f", "")
+}
+
+// LookupGitmoji returns the gitmoji of an emoji or a shortcode, or nil
+func LookupGitmoji(emoji string) *Gitmoji {
+	emoji = normalizeEmoji(emoji)
+
+	for i := range Gitmojis {
+		g := &Gitmojis[i]
+
+		if normalizeEmoji(g.Emoji) == emoji || g.Code == emoji {
+			return g
+		}
+	}
+
+	return nil
+}
+
+func isEmojiRune(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, flags...
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols, dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // miscellaneous technical
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // arrows, stars
+		return true
+	case r >= 0x2190 && r <= 0x21FF: // arrows
+		return true
+	case r >= 0x25A0 && r <= 0x25FF: // geometric shapes
+		return true
+	}
+
+	switch r {
+	case 0x00A9, 0x00AE, 0x203C, 0x2049, 0x2122, 0x2139, 0x24C2, 0x2934, 0x2935, 0x3030, 0x303D, 0x3297, 0x3299:
+		return true
+	}
+
+	return false
+}
+
+// isEmojiModifier reports whether the rune extends the previous emoji:
+// variation selectors, skin tones, keycaps and tags
+func isEmojiModifier(r rune) bool {
+	return r == 0xFE0F || r == 0xFE0E || r == 0x20E3 || (r >= 0x1F3FB && r <= 0x1F3FF) || (r >= 0xE0020 && r <= 0xE007F)
+}
+
+// emojiPrefixLen returns the length in bytes of the emoji sequence at the
+// start of txt, zero width joined sequences included
+func emojiPrefixLen(txt string) int {
+	n := 0
+
+	for {
+		r, size := utf8.DecodeRuneInString(txt[n:])
+		if !isEmojiRune(r) {
+			return n
+		}
+		n += size
+
+		for {
+			r, size = utf8.DecodeRuneInString(txt[n:])
+			if !isEmojiModifier(r) {
+				break
+			}
+			n += size
+		}
+
+		// zero width joiner, 🧑‍💻
+		if r, size = utf8.DecodeRuneInString(txt[n:]); r != 0x200D {
+			return n
+		}
+
+		if next, _ := utf8.DecodeRuneInString(txt[n+size:]); !isEmojiRune(next) {
+			return n
+		}
+		n += size
+	}
+}
+
+// isEmojiPresentation reports whether the emoji sequence displays as an
+// emoji rather than as a text symbol like `©` or `⌘`: a pictograph, or a
+// sequence with a variation selector, a keycap or a zero width joiner
+func isEmojiPresentation(emoji string) bool {
+	if r, _ := utf8.DecodeRuneInString(emoji); r >= 0x1F000 {
+		return true
+	}
+
+	return strings.ContainsAny(emoji, "\ufe0f\u20e3\u200d")
+}
+
+// splitEmoji splits the leading emoji or `:shortcode:` of a header. An
+// emoji is split when it is a gitmoji, or when it displays as an emoji and
+// is followed by whitespace, `⌘K opens the palette` has no emoji.
+func splitEmoji(txt string) (string, string) {
+	trimmed := strings.TrimLeft(txt, " \t")
+
+	if code := emojiShortcodePattern.FindString(trimmed); code != "" {
+		return code, strings.TrimLeft(trimmed[len(code):], " \t")
+	}
+
+	n := emojiPrefixLen(trimmed)
+	if n == 0 {
+		return "", txt
+	}
+
+	rest := trimmed[n:]
+	spaced := rest == "" || rest[0] == ' ' || rest[0] == '\t'
+
+	if LookupGitmoji(trimmed[:n]) != nil || (spaced && isEmojiPresentation(trimmed[:n])) {
+		return trimmed[:n], strings.TrimLeft(rest, " \t")
+	}
+
+	return "", txt
+}
@@ -0,0 +1,106 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeaderGitmoji(t *testing.T) {
+	tests := []struct {
+		txt  string
+		want Header
+	}{
+		{
+			txt:  "✨ feat(ui): add dark mode",
+			want: Header{Type: "feat", Scope: "ui", Subject: "add dark mode", Emoji: "✨"},
+		},
+		{
+			txt:  ":bug: fix: crash on empty input",
+			want: Header{Type: "fix", Subject: "crash on empty input", Emoji: ":bug:"},
+		},
+		{
+			txt:  "🐛 Fix login",
+			want: Header{Type: "fix", Subject: "Fix login", Emoji: "🐛"},
+		},
+		{
+			txt:  "⚡ speed up parsing",
+			want: Header{Type: "perf", Subject: "speed up parsing", Emoji: "⚡"},
+		},
+		{
+			txt:  "⚡️speed up parsing",
+			want: Header{Type: "perf", Subject: "speed up parsing", Emoji: "⚡️"},
+		},
+		{
+			txt:  "💥 drop v1",
+			want: Header{Type: "feat", Subject: "drop v1", Important: true, Emoji: "💥"},
+		},
+		{
+			txt:  "🧑‍💻 improve dev scripts",
+			want: Header{Subject: "improve dev scripts", Emoji: "🧑‍💻"},
+		},
+		{
+			txt:  "👍🏽 chore: bump deps",
+			want: Header{Type: "chore", Subject: "bump deps", Emoji: "👍🏽"},
+		},
+		{
+			txt:  ":unknown: update",
+			want: Header{Subject: "update", Emoji: ":unknown:"},
+		},
+		{
+			txt:  "feat: ✨ add dark mode",
+			want: Header{Type: "feat", Subject: "✨ add dark mode"},
+		},
+		{
+			txt:  "⌘K opens the palette",
+			want: Header{Subject: "⌘K opens the palette"},
+		},
+		{
+			txt:  "© 2024 update year",
+			want: Header{Subject: "© 2024 update year"},
+		},
+		{
+			txt:  "→ docs: arrows",
+			want: Header{Subject: "→ docs: arrows"},
+		},
+		{
+			txt:  "👍🏽chore: bump deps",
+			want: Header{Subject: "👍🏽chore: bump deps"},
+		},
+		{
+			txt:  "⏪ Revert \"feat: x\"",
+			want: Header{Type: "revert", Subject: "feat: x", Emoji: "⏪"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txt, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseHeader(tt.txt))
+		})
+	}
+}
+
+func TestLookupGitmoji(t *testing.T) {
+	assert.Equal(t, "fix", LookupGitmoji("🐛").Type)
+	assert.Equal(t, "fix", LookupGitmoji(":bug:").Type)
+	assert.Equal(t, "perf", LookupGitmoji("⚡").Type)
+	assert.Equal(t, "perf", LookupGitmoji("⚡️").Type)
+	assert.Nil(t, LookupGitmoji("🦄"))
+
+	defer func(gitmojis []Gitmoji) { Gitmojis = gitmojis }(Gitmojis)
+	Gitmojis = append(Gitmojis, Gitmoji{Emoji: "🦄", Code: ":unicorn:", Type: "feat"})
+
+	assert.Equal(t, Header{Type: "feat", Subject: "add magic", Emoji: ":unicorn:"}, parseHeader(":unicorn: add magic"))
+}
+
+func TestBuildChangelogEmoji(t *testing.T) {
+	commits := []*Commit{
+		{Message: Parse("✨ feat: add dark mode")},
+		{Message: Parse("🐛 Fix login")},
+		{Message: Parse("💥 drop v1")},
+	}
+
+	assert.Equal(t, "### 💥 BREAKING CHANGES\n\n* drop v1\n\n"+
+		"### ✨ Features\n\n* add dark mode\n* drop v1\n\n"+
+		"### 🐛 Bug Fixes\n\n* Fix login\n",
+		RenderChangelog(BuildChangelog(commits, ChangelogOptions{Emoji: true})))
+}
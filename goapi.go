@@ -0,0 +1,313 @@
+package conventionalcommitparser
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// APIChange is a change of the exported API of a Go package
+type APIChange struct {
+	// Name of the changed object, `Parse`, `Header.Type` or `Message.Parsed`
+	Name string
+	// Compatible changes, most additions, do not break the code using the package
+	Compatible bool
+	// Message describes the change, `removed` or `changed from func(string) to func(string, int)`
+	Message string
+}
+
+func (c APIChange) String() string {
+	return c.Name + ": " + c.Message
+}
+
+// apiElement is an exported object of a package, as compared by CompareGoAPI
+type apiElement struct {
+	// desc is what is compared, `func(string) *Message`
+	desc string
+	// implementable reports a method of an interface other packages can implement
+	implementable bool
+	// pointer reports a method with a pointer receiver
+	pointer bool
+}
+
+// loadGoAPI type-checks the package of the directory and returns its exported
+// objects by name. Imports are type-checked from source. The errors of the
+// type-check are ignored unless they leave a type of the exported API
+// unresolved, as an unresolved type cannot be compared, an error is returned.
+func loadGoAPI(dir string) (map[string]apiElement, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("load go api of %s: %w", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(pkg.GoFiles))
+
+	for _, name := range pkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("load go api of %s: %w", dir, err)
+		}
+
+		files = append(files, file)
+	}
+
+	typeErrors := make([]error, 0)
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// an error in a function body does not change the API
+		Error: func(err error) { typeErrors = append(typeErrors, err) },
+	}
+
+	checked, _ := conf.Check(pkg.ImportPath, fset, files, nil)
+	qualifier := func(p *types.Package) string {
+		if p == checked {
+			return ""
+		}
+
+		return p.Name()
+	}
+
+	elements := make(map[string]apiElement)
+	scope := checked.Scope()
+
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+
+		if !obj.Exported() {
+			continue
+		}
+
+		switch obj := obj.(type) {
+		case *types.Const:
+			elements[name] = apiElement{desc: "const " + types.TypeString(obj.Type(), qualifier)}
+		case *types.Var:
+			elements[name] = apiElement{desc: "var " + types.TypeString(obj.Type(), qualifier)}
+		case *types.Func:
+			elements[name] = apiElement{desc: signatureString(obj.Type().(*types.Signature), qualifier)}
+		case *types.TypeName:
+			addTypeElements(elements, obj, qualifier)
+		}
+	}
+
+	for _, name := range sortedElementNames(elements) {
+		if !strings.Contains(elements[name].desc, "invalid type") {
+			continue
+		}
+
+		cause := errors.New("invalid type")
+		if len(typeErrors) != 0 {
+			cause = typeErrors[0]
+		}
+
+		return nil, fmt.Errorf("load go api of %s: unresolved type of %s: %w", dir, name, cause)
+	}
+
+	return elements, nil
+}
+
+func sortedElementNames(elements map[string]apiElement) []string {
+	names := make([]string, 0, len(elements))
+
+	for name := range elements {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// addTypeElements adds a type along with its exported fields and methods
+func addTypeElements(elements map[string]apiElement, obj *types.TypeName, qualifier types.Qualifier) {
+	name := obj.Name()
+
+	if obj.IsAlias() {
+		elements[name] = apiElement{desc: "= " + types.TypeString(obj.Type(), qualifier)}
+		return
+	}
+
+	switch underlying := obj.Type().Underlying().(type) {
+	case *types.Struct:
+		elements[name] = apiElement{desc: "struct"}
+
+		for i := 0; i < underlying.NumFields(); i++ {
+			if field := underlying.Field(i); field.Exported() {
+				elements[name+"."+field.Name()] = apiElement{desc: types.TypeString(field.Type(), qualifier)}
+			}
+		}
+	case *types.Interface:
+		elements[name] = apiElement{desc: "interface"}
+		sealed := false
+
+		for i := 0; i < underlying.NumMethods(); i++ {
+			sealed = sealed || !underlying.Method(i).Exported()
+		}
+
+		for i := 0; i < underlying.NumMethods(); i++ {
+			if method := underlying.Method(i); method.Exported() {
+				elements[name+"."+method.Name()] = apiElement{
+					desc:          signatureString(method.Type().(*types.Signature), qualifier),
+					implementable: !sealed,
+				}
+			}
+		}
+	default:
+		elements[name] = apiElement{desc: types.TypeString(underlying, qualifier)}
+	}
+
+	if named, ok := obj.Type().(*types.Named); ok {
+		for i := 0; i < named.NumMethods(); i++ {
+			method := named.Method(i)
+
+			if !method.Exported() {
+				continue
+			}
+
+			sig := method.Type().(*types.Signature)
+			_, pointer := sig.Recv().Type().(*types.Pointer)
+			elements[name+"."+method.Name()] = apiElement{desc: signatureString(sig, qualifier), pointer: pointer}
+		}
+	}
+}
+
+// signatureString formats a signature without the names of the parameters
+// and the receiver, `func(string, ...int) (*Message, error)`
+func signatureString(sig *types.Signature, qualifier types.Qualifier) string {
+	tuple := func(t *types.Tuple, variadic bool) []string {
+		list := make([]string, t.Len())
+
+		for i := range list {
+			typ := t.At(i).Type()
+
+			if variadic && i == len(list)-1 {
+				list[i] = "..." + types.TypeString(typ.(*types.Slice).Elem(), qualifier)
+			} else {
+				list[i] = types.TypeString(typ, qualifier)
+			}
+		}
+
+		return list
+	}
+
+	s := "func(" + strings.Join(tuple(sig.Params(), sig.Variadic()), ", ") + ")"
+
+	switch results := tuple(sig.Results(), false); len(results) {
+	case 0:
+	case 1:
+		s += " " + results[0]
+	default:
+		s += " (" + strings.Join(results, ", ") + ")"
+	}
+
+	return s
+}
+
+// CompareGoAPI compares the exported API of the Go package of oldDir with
+// the one of newDir, two checkouts of the same package. Additions are
+// compatible, except new methods of interfaces other packages can implement,
+// removals and changes are incompatible, except a method moving from a
+// pointer receiver to a value receiver. The changes are sorted by name.
+// An error is returned when a type of the exported API cannot be resolved,
+// a missing dependency, as its changes would go unnoticed.
+func CompareGoAPI(oldDir, newDir string) ([]APIChange, error) {
+	before, err := loadGoAPI(oldDir)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := loadGoAPI(newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]APIChange, 0)
+
+	for name, old := range before {
+		current, ok := after[name]
+
+		switch {
+		case !ok:
+			changes = append(changes, APIChange{Name: name, Message: "removed"})
+		case old.desc != current.desc:
+			changes = append(changes, APIChange{Name: name, Message: fmt.Sprintf("changed from %s to %s", old.desc, current.desc)})
+		case old.pointer != current.pointer:
+			changes = append(changes, APIChange{
+				Name:       name,
+				Compatible: old.pointer,
+				Message:    fmt.Sprintf("receiver changed from %s to %s", receiverKind(old.pointer), receiverKind(current.pointer)),
+			})
+		}
+	}
+
+	for name, current := range after {
+		if _, ok := before[name]; ok {
+			continue
+		}
+
+		if current.implementable {
+			changes = append(changes, APIChange{Name: name, Message: "added to an interface, the implementations of other packages lack it"})
+		} else {
+			changes = append(changes, APIChange{Name: name, Compatible: true, Message: "added"})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes, nil
+}
+
+func receiverKind(pointer bool) string {
+	if pointer {
+		return "pointer"
+	}
+
+	return "value"
+}
+
+// VerifyBreakingChanges cross-checks the API changes of a package with the
+// commits changing it, see CompareGoAPI. Incompatible changes call for a
+// breaking commit, marked with `!` or a BREAKING CHANGE footer, and breaking
+// commits call for an incompatible change.
+func VerifyBreakingChanges(changes []APIChange, commits []*Commit) []string {
+	problems := make([]string, 0)
+	breaking := make([]*Commit, 0)
+
+	for _, c := range commits {
+		if c.Message.IsBreaking() {
+			breaking = append(breaking, c)
+		}
+	}
+
+	incompatible := make([]APIChange, 0)
+
+	for _, change := range changes {
+		if !change.Compatible {
+			incompatible = append(incompatible, change)
+		}
+	}
+
+	if len(breaking) == 0 {
+		for _, change := range incompatible {
+			problems = append(problems, fmt.Sprintf("incompatible change %s, but no commit is marked as breaking", change))
+		}
+	}
+
+	if len(incompatible) == 0 {
+		for _, c := range breaking {
+			problems = append(problems, fmt.Sprintf("commit %s %q is marked as breaking, but the API has no incompatible change", shortHash(c.Hash), c.Message.Header))
+		}
+	}
+
+	return problems
+}
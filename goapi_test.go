@@ -0,0 +1,154 @@
+package conventionalcommitparser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFiles writes the files, by slash-separated path, to a temporary directory
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		assert.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+const goAPIBefore = `package api
+
+import "strings"
+
+const Version = "1"
+
+var Default = New("x")
+
+type Client struct {
+	Name    string
+	Timeout int
+	secret  string
+}
+
+func New(name string) *Client { return &Client{Name: strings.TrimSpace(name)} }
+
+func (c *Client) Do(path string, args ...string) (string, error) { return "", nil }
+
+func (c *Client) Close() {}
+
+type Doer interface {
+	Do(path string, args ...string) (string, error)
+}
+
+type sealed interface {
+	seal()
+}
+
+type Sealed interface {
+	sealed
+	Name() string
+}
+
+type Level int
+
+func Removed() {}
+`
+
+const goAPIAfter = `package api
+
+import "strings"
+
+const Version = "2"
+
+var Default = New("x", 1)
+
+type Client struct {
+	Name    string
+	Timeout int64
+	Retries int
+}
+
+func New(name string, retries int) *Client { return &Client{Name: strings.TrimSpace(name)} }
+
+func (c *Client) Do(p string, rest ...string) (string, error) { return "", nil }
+
+func (c Client) Close() {}
+
+type Doer interface {
+	Do(path string, args ...string) (string, error)
+	Close()
+}
+
+type Sealed interface {
+	seal()
+	Name() string
+	Kind() Level
+}
+
+type Level string
+
+func Added() {}
+`
+
+func TestCompareGoAPI(t *testing.T) {
+	before := writeFiles(t, map[string]string{"api.go": goAPIBefore, "api_test.go": "package api\n\nfunc TestX() {}\n"})
+	after := writeFiles(t, map[string]string{"api.go": goAPIAfter})
+
+	changes, err := CompareGoAPI(before, after)
+	assert.NoError(t, err)
+	assert.Equal(t, []APIChange{
+		{Name: "Added", Compatible: true, Message: "added"},
+		{Name: "Client.Close", Compatible: true, Message: "receiver changed from pointer to value"},
+		{Name: "Client.Retries", Compatible: true, Message: "added"},
+		{Name: "Client.Timeout", Message: "changed from int to int64"},
+		{Name: "Doer.Close", Message: "added to an interface, the implementations of other packages lack it"},
+		{Name: "Level", Message: "changed from int to string"},
+		{Name: "New", Message: "changed from func(string) *Client to func(string, int) *Client"},
+		{Name: "Removed", Message: "removed"},
+		{Name: "Sealed.Kind", Compatible: true, Message: "added"},
+	}, changes)
+
+	changes, err = CompareGoAPI(before, before)
+	assert.NoError(t, err)
+	assert.Equal(t, []APIChange{}, changes)
+
+	_, err = CompareGoAPI(before, t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestCompareGoAPIUnresolvedTypes(t *testing.T) {
+	before := writeFiles(t, map[string]string{"api.go": "package api\n\nimport \"example.com/dep\"\n\nfunc F(dep.A) {}\n\ntype S struct{ X dep.A }\n"})
+	after := writeFiles(t, map[string]string{"api.go": "package api\n\nimport \"example.com/dep\"\n\nfunc F(dep.B) {}\n\ntype S struct{ X dep.C }\n"})
+
+	_, err := CompareGoAPI(before, after)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unresolved type of F")
+	}
+
+	// errors in the function bodies leave the API alone
+	body := writeFiles(t, map[string]string{"api.go": "package api\n\nfunc F() int { return undefined }\n"})
+	changes, err := CompareGoAPI(body, body)
+	assert.NoError(t, err)
+	assert.Equal(t, []APIChange{}, changes)
+}
+
+func TestVerifyBreakingChanges(t *testing.T) {
+	incompatible := []APIChange{{Name: "Removed", Message: "removed"}, {Name: "Added", Compatible: true, Message: "added"}}
+	compatible := []APIChange{{Name: "Added", Compatible: true, Message: "added"}}
+	fix := &Commit{Hash: "1111111aaaa", Message: Parse("fix: x")}
+	breaking := &Commit{Hash: "2222222bbbb", Message: Parse("feat!: drop Removed")}
+
+	assert.Equal(t, []string{}, VerifyBreakingChanges(incompatible, []*Commit{fix, breaking}))
+	assert.Equal(t, []string{}, VerifyBreakingChanges(compatible, []*Commit{fix}))
+	assert.Equal(t, []string{
+		"incompatible change Removed: removed, but no commit is marked as breaking",
+	}, VerifyBreakingChanges(incompatible, []*Commit{fix}))
+	assert.Equal(t, []string{
+		`commit 2222222 "feat!: drop Removed" is marked as breaking, but the API has no incompatible change`,
+	}, VerifyBreakingChanges(compatible, []*Commit{fix, breaking}))
+}
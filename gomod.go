@@ -0,0 +1,240 @@
+package conventionalcommitparser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// GoModule is a Go module of a repository
+type GoModule struct {
+	// Path of the module, `github.com/org/repo/v2`
+	Path string
+	// Dir of the module, slash-separated and relative to the root of the
+	// repository, `.` or `tools/cli`
+	Dir string
+}
+
+// goModDirectives returns the arguments of the directives of a go.mod or
+// go.work file, `module` or `use`, including the ones of blocks
+func goModDirectives(data string, directive string) []string {
+	args := make([]string, 0)
+	block := false
+
+	for _, line := range splitToLines(data) {
+		if i := strings.Index(line, "//"); i != -1 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+
+		switch {
+		case block && len(fields) == 1 && fields[0] == ")":
+			block = false
+		case block && len(fields) != 0:
+			args = append(args, unquoteGoModArg(fields[0]))
+		case len(fields) == 2 && fields[0] == directive && fields[1] == "(":
+			block = true
+		case len(fields) >= 2 && fields[0] == directive:
+			args = append(args, unquoteGoModArg(fields[1]))
+		}
+	}
+
+	return args
+}
+
+func unquoteGoModArg(arg string) string {
+	if unquoted, err := strconv.Unquote(arg); err == nil {
+		return unquoted
+	}
+
+	return arg
+}
+
+// readGoModule reads the go.mod file of the directory dir of the root
+func readGoModule(root, dir string) (GoModule, error) {
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(dir), "go.mod"))
+	if err != nil {
+		return GoModule{}, err
+	}
+
+	paths := goModDirectives(string(data), "module")
+	if len(paths) == 0 {
+		return GoModule{}, fmt.Errorf("%s: no module directive", path.Join(dir, "go.mod"))
+	}
+
+	return GoModule{Path: paths[0], Dir: path.Clean(dir)}, nil
+}
+
+// ReadGoModules reads the modules of a directory: the members of its
+// go.work file, or the module of its go.mod file
+func ReadGoModules(dir string) ([]GoModule, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.work"))
+
+	if errors.Is(err, os.ErrNotExist) {
+		module, err := readGoModule(dir, ".")
+		if err != nil {
+			return nil, err
+		}
+
+		return []GoModule{module}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make([]GoModule, 0)
+
+	for _, use := range goModDirectives(string(data), "use") {
+		module, err := readGoModule(dir, filepath.ToSlash(use))
+		if err != nil {
+			return nil, err
+		}
+
+		modules = append(modules, module)
+	}
+
+	return modules, nil
+}
+
+// ModulePathMajor returns the major version of the versions of a module:
+// N for `example.com/mod/vN` and `gopkg.in/mod.vN`, 0 when the path has no
+// major version suffix and the versions are v0 or v1
+func ModulePathMajor(modulePath string) int {
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		if i := strings.LastIndex(modulePath, ".v"); i != -1 {
+			if n, err := strconv.Atoi(modulePath[i+2:]); err == nil && n >= 0 {
+				return n
+			}
+		}
+
+		return 0
+	}
+
+	i := strings.LastIndex(modulePath, "/v")
+	if i == -1 {
+		return 0
+	}
+
+	suffix := modulePath[i+2:]
+	if n, err := strconv.Atoi(suffix); err == nil && n >= 2 && suffix[0] != '0' && suffix[0] != '+' {
+		return n
+	}
+
+	return 0
+}
+
+// CheckModuleVersion reports why the module cannot be tagged with the
+// version: a v2 or later version needs the `/vN` suffix of the module path,
+// and a path with a suffix only takes versions of its major
+func CheckModuleVersion(modulePath string, v Version) []string {
+	problems := make([]string, 0)
+	major := ModulePathMajor(modulePath)
+
+	mismatch := fmt.Sprintf("version %s does not match the module path %s, which is for v%d versions", v, modulePath, major)
+
+	// gopkg.in/mod.v1 takes v0 versions too
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		if v.Major != major && !(major == 1 && v.Major == 0) {
+			problems = append(problems, mismatch)
+		}
+
+		return problems
+	}
+
+	switch {
+	case major == 0 && v.Major >= 2:
+		problems = append(problems, fmt.Sprintf("version %s needs the module path %s/v%d, the module path is %s", v, modulePath, v.Major, modulePath))
+	case major >= 2 && v.Major != major:
+		problems = append(problems, mismatch)
+	}
+
+	return problems
+}
+
+// nextModuleVersion returns the version of the module following the current
+// one after the bump, along with its problems, see CheckModuleVersion. The
+// first release of a /vN module, one without current version, is vN.0.0.
+// Without bump the version is unchanged and has no problem.
+func nextModuleVersion(m GoModule, current Version, found bool, bump Bump) (Version, []string) {
+	if bump == BumpNone {
+		return current, make([]string, 0)
+	}
+
+	next := current.Next(bump)
+
+	if major := ModulePathMajor(m.Path); !found && major >= 2 {
+		next = Version{Major: major}
+	}
+
+	return next, CheckModuleVersion(m.Path, next)
+}
+
+// ModuleVersionCheck is the next version of a module, see CheckGoModules
+type ModuleVersionCheck struct {
+	Module   GoModule
+	Current  Version
+	Next     Version
+	Problems []string
+}
+
+// dirDepth returns the number of directories of a slash-separated path, 0 for `.`
+func dirDepth(dir string) int {
+	if dir == "." {
+		return 0
+	}
+
+	return strings.Count(dir, "/") + 1
+}
+
+// moduleOfPath returns the index of the module containing the slash-separated
+// path, the deepest one, or -1
+func moduleOfPath(modules []GoModule, p string) int {
+	best := -1
+	p = path.Clean(p)
+
+	for i, m := range modules {
+		if m.Dir != "." && p != m.Dir && !strings.HasPrefix(p, m.Dir+"/") {
+			continue
+		}
+
+		if best == -1 || dirDepth(m.Dir) > dirDepth(modules[best].Dir) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// CheckGoModules reads the modules of the directory, see ReadGoModules, and
+// checks the version following the commits of each module against its path,
+// see CheckModuleVersion. The current versions are keyed by module path,
+// modules without version start at v0.0.0, or at vN.0.0 for a /vN module. The paths of the commits, when
+// known, are relative to the directory, see AttributeCommits. The version
+// follows the types of the options and the same commits as the changelog,
+// see ChangelogOptions.ExpandSquashes and ChangelogOptions.CancelReverts.
+func CheckGoModules(dir string, current map[string]Version, commits []*Commit, opts ChangelogOptions) ([]ModuleVersionCheck, error) {
+	modules, err := ReadGoModules(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	types := opts.types()
+
+	checks := make([]ModuleVersionCheck, 0, len(modules))
+	attributed := AttributeCommits(modules, commits)
+
+	for i, m := range modules {
+		version, found := current[m.Path]
+		check := ModuleVersionCheck{Module: m, Current: version}
+		check.Next, check.Problems = nextModuleVersion(m, version, found, types.BumpCommits(opts.history(attributed[i])))
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,142 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGoModules(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"go.mod": "// the parser\nmodule \"example.com/parser/v2\" // v2\n\ngo 1.17\n"})
+
+	modules, err := ReadGoModules(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []GoModule{{Path: "example.com/parser/v2", Dir: "."}}, modules)
+
+	dir = writeFiles(t, map[string]string{
+		"go.work":           "go 1.18\n\nuse (\n\t.\n\t./tools/cli // the cli\n)\n\nuse ./lib\n",
+		"go.mod":            "module example.com/repo\n",
+		"tools/cli/go.mod":  "module example.com/repo/tools/cli\r\n",
+		"lib/go.mod":        "module example.com/repo/lib/v3\n",
+		"unused/go.mod":     "module example.com/repo/unused\n",
+		"tools/cli/main.go": "package main\n",
+	})
+
+	modules, err = ReadGoModules(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []GoModule{
+		{Path: "example.com/repo", Dir: "."},
+		{Path: "example.com/repo/tools/cli", Dir: "tools/cli"},
+		{Path: "example.com/repo/lib/v3", Dir: "lib"},
+	}, modules)
+
+	_, err = ReadGoModules(t.TempDir())
+	assert.Error(t, err)
+
+	_, err = ReadGoModules(writeFiles(t, map[string]string{"go.mod": "go 1.17\n"}))
+	assert.EqualError(t, err, "go.mod: no module directive")
+}
+
+func TestModulePathMajor(t *testing.T) {
+	for p, want := range map[string]int{
+		"example.com/mod":      0,
+		"example.com/mod/v2":   2,
+		"example.com/mod/v10":  10,
+		"example.com/mod/v1":   0,
+		"example.com/mod/v02":  0,
+		"example.com/v2/mod":   0,
+		"example.com/mod/vx":   0,
+		"gopkg.in/yaml.v3":     3,
+		"gopkg.in/check.v1":    1,
+		"gopkg.in/src-d/go.v4": 4,
+	} {
+		assert.Equal(t, want, ModulePathMajor(p), p)
+	}
+}
+
+func TestCheckModuleVersion(t *testing.T) {
+	assert.Equal(t, []string{}, CheckModuleVersion("example.com/mod", Version{Major: 1, Minor: 4}))
+	assert.Equal(t, []string{}, CheckModuleVersion("example.com/mod/v2", Version{Major: 2, Minor: 1}))
+	assert.Equal(t, []string{}, CheckModuleVersion("gopkg.in/check.v1", Version{Minor: 4}))
+	assert.Equal(t, []string{
+		"version v2.0.0 needs the module path example.com/mod/v2, the module path is example.com/mod",
+	}, CheckModuleVersion("example.com/mod", Version{Major: 2}))
+	assert.Equal(t, []string{
+		"version v3.0.0 does not match the module path example.com/mod/v2, which is for v2 versions",
+	}, CheckModuleVersion("example.com/mod/v2", Version{Major: 3}))
+	assert.Equal(t, []string{
+		"version v4.0.0 does not match the module path gopkg.in/yaml.v3, which is for v3 versions",
+	}, CheckModuleVersion("gopkg.in/yaml.v3", Version{Major: 4}))
+}
+
+func TestCheckGoModules(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"go.work":          "use (\n\t.\n\t./tools/cli\n)\n",
+		"go.mod":           "module example.com/repo\n",
+		"tools/cli/go.mod": "module example.com/repo/tools/cli/v2\n",
+	})
+
+	commits := []*Commit{
+		{Message: Parse("feat(cli)!: drop flags"), Paths: []string{"tools/cli/main.go"}},
+		{Message: Parse("fix: x"), Paths: []string{"parser.go", "tools/README.md"}},
+	}
+
+	checks, err := CheckGoModules(dir, map[string]Version{
+		"example.com/repo":              {Major: 1, Minor: 4},
+		"example.com/repo/tools/cli/v2": {Major: 2, Minor: 1},
+	}, commits, ChangelogOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ModuleVersionCheck{
+		{
+			Module:   GoModule{Path: "example.com/repo", Dir: "."},
+			Current:  Version{Major: 1, Minor: 4},
+			Next:     Version{Major: 1, Minor: 4, Patch: 1},
+			Problems: []string{},
+		},
+		{
+			Module:  GoModule{Path: "example.com/repo/tools/cli/v2", Dir: "tools/cli"},
+			Current: Version{Major: 2, Minor: 1},
+			Next:    Version{Major: 3},
+			Problems: []string{
+				"version v3.0.0 does not match the module path example.com/repo/tools/cli/v2, which is for v2 versions",
+			},
+		},
+	}, checks)
+
+	// a prerelease does not cover a breaking change
+	checks, err = CheckGoModules(dir, map[string]Version{"example.com/repo": {Major: 1, Minor: 3, Prerelease: "rc.1"}}, []*Commit{{Message: Parse("feat!: x")}}, ChangelogOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 2}, checks[0].Next)
+	assert.Equal(t, []string{"version v2.0.0 needs the module path example.com/repo/v2, the module path is example.com/repo"}, checks[0].Problems)
+
+	// without paths, the commits change every module
+	checks, err = CheckGoModules(dir, map[string]Version{"example.com/repo": {Major: 1}}, []*Commit{{Message: Parse("fix!: x")}}, ChangelogOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"version v2.0.0 needs the module path example.com/repo/v2, the module path is example.com/repo"}, checks[0].Problems)
+	assert.Equal(t, Version{Major: 2}, checks[1].Next)
+	assert.Equal(t, []string{}, checks[1].Problems)
+
+	// the first release of a /vN module is vN.0.0, the same as PlanModuleReleases
+	checks, err = CheckGoModules(dir, nil, []*Commit{{Message: Parse("feat(cli): x")}}, ChangelogOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 2}, checks[1].Next)
+	assert.Equal(t, []string{}, checks[1].Problems)
+	assert.Equal(t, PlanModuleReleases([]GoModule{checks[1].Module}, nil, []*Commit{{Message: Parse("feat(cli): x")}}, ChangelogOptions{})[0].Next, checks[1].Next)
+
+	// nothing to release, nothing to report
+	checks, err = CheckGoModules(dir, nil, nil, ChangelogOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Version{}, checks[1].Next)
+	assert.Equal(t, []string{}, checks[1].Problems)
+
+	// the bump follows the commits of the changelog
+	reverted := []*Commit{
+		{Hash: "1111111", Message: Parse("feat!: x")},
+		{Hash: "2222222", Message: Parse("Revert \"feat!: x\"\n\nThis reverts commit 1111111.")},
+	}
+	checks, err = CheckGoModules(dir, map[string]Version{"example.com/repo": {Major: 1}}, reverted, ChangelogOptions{CancelReverts: true})
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 1}, checks[0].Next)
+	assert.Equal(t, []string{}, checks[0].Problems)
+}
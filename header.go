@@ -10,28 +10,100 @@ type Header struct {
 	Scope     string
 	Subject   string
 	Important bool
+	// Emoji is the leading emoji or `:shortcode:` of the header, `✨ feat: x`
+	Emoji string
 }
 
-var (
-	headerPattern       = regexp.MustCompile(`^(?i)([\s\w-]*)(\((.*)\))?(!?):\s+(.*)$`)
-	revertHeaderPattern = regexp.MustCompile(`^(?i)revert\s(.*)$`)
-)
+// HeaderSyntax describes the characters allowed in the type and the scope
+// of a header, as the content of regexp character classes
+type HeaderSyntax struct {
+	// TypeCharacters of the type, `feat`
+	TypeCharacters string
+	// ScopeCharacters of the scope, any character when empty
+	ScopeCharacters string
+}
+
+// DefaultHeaderSyntax accepts letters and numbers of any script,
+// `功能(界面): 添加按钮` or `évol(café): ajoute`
+var DefaultHeaderSyntax = HeaderSyntax{
+	TypeCharacters: `\s\p{L}\p{M}\p{N}_-`,
+}
+
+// ASCIIHeaderSyntax only accepts ASCII letters and numbers in the type
+var ASCIIHeaderSyntax = HeaderSyntax{
+	TypeCharacters: `\s\w-`,
+}
+
+// HeaderParser parses headers following a HeaderSyntax
+type HeaderParser struct {
+	// pattern is nil for DefaultHeaderSyntax, which is scanned by hand
+	pattern *regexp.Regexp
+}
+
+// NewHeaderParser compiles the syntax, an error is returned when the
+// character classes are not valid regexp
+func NewHeaderParser(syntax HeaderSyntax) (*HeaderParser, error) {
+	scope := ".*"
+	if syntax.ScopeCharacters != "" {
+		scope = "[" + syntax.ScopeCharacters + "]*"
+	}
+
+	pattern, err := regexp.Compile(`^(?i)([` + syntax.TypeCharacters + `]*)(\((` + scope + `)\))?(!?):\s+(.*)$`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HeaderParser{pattern: pattern}, nil
+}
+
+var defaultHeaderParser = &HeaderParser{}
+
+// match returns the type, the scope, the `!` and the subject of a conventional header
+func (p *HeaderParser) match(txt string) (string, string, bool, string, bool) {
+	if p.pattern == nil {
+		return scanConventionalHeader(txt)
+	}
+
+	matcher := p.pattern.FindStringSubmatch(txt)
+	if matcher == nil {
+		return "", "", false, "", false
+	}
+
+	return matcher[1], matcher[3], matcher[4] == "!", matcher[5], true
+}
 
 func parseHeader(txt string) Header {
-	headerMatchers := headerPattern.FindStringSubmatch(txt)
-	revertHeaderMatchers := revertHeaderPattern.FindStringSubmatch(txt)
+	return defaultHeaderParser.Parse(txt)
+}
+
+// Parse parses a header
+func (p *HeaderParser) Parse(txt string) Header {
+	if emoji, rest := splitEmoji(txt); emoji != "" {
+		header := p.Parse(rest)
+		header.Emoji = emoji
+
+		if g := LookupGitmoji(emoji); g != nil {
+			// gitmoji commit, `🐛 Fix login`
+			if header.Type == "" {
+				header.Type = g.Type
+			}
+
+			header.Important = header.Important || g.Breaking
+		}
+
+		return header
+	}
+
 	header := Header{}
 
-	if len(headerMatchers) != 0 { // conventional commit
-		header.Type = strings.TrimSpace(strings.ToLower(headerMatchers[1]))
-		header.Scope = strings.TrimSpace(headerMatchers[3])
-		header.Important = headerMatchers[4] == "!"
-		header.Subject = headerMatchers[5]
-	} else if len(revertHeaderMatchers) != 0 { // revert commit
-		subject := strings.Trim(revertHeaderMatchers[1], "\"")
-		subject = strings.Trim(subject, "'")
-		header.Type = "revert"
+	if kind, scope, important, subject, ok := p.match(txt); ok { // conventional commit
+		header.Type = strings.TrimSpace(strings.ToLower(kind))
+		header.Scope = strings.TrimSpace(scope)
+		header.Important = important
 		header.Subject = subject
+	} else if subject, ok := scanRevertHeader(txt); ok { // revert commit
+		header.Type = "revert"
+		header.Subject = unquoteRevertSubject(subject)
 	} else { // commom commit
 		header.Type = ""
 		header.Scope = ""
@@ -40,3 +112,20 @@ func parseHeader(txt string) Header {
 
 	return header
 }
+
+// unquoteRevertSubject removes one layer of quotes, so that the subject of
+// `Revert "Revert "feat: x""` is `Revert "feat: x"`
+func unquoteRevertSubject(subject string) string {
+	if len(subject) >= 2 {
+		first, last := subject[0], subject[len(subject)-1]
+
+		if (first == '"' || first == '\'') && first == last {
+			return subject[1 : len(subject)-1]
+		}
+	}
+
+	subject = strings.Trim(subject, "\"")
+	subject = strings.Trim(subject, "'")
+
+	return subject
+}
@@ -125,3 +125,79 @@ func TestParseHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestParseHeaderUnicode(t *testing.T) {
+	tests := []struct {
+		txt  string
+		want Header
+	}{
+		{
+			txt:  "功能(界面): 添加按钮",
+			want: Header{Type: "功能", Scope: "界面", Subject: "添加按钮"},
+		},
+		{
+			txt:  "Évol(café)!: ajoute le menu",
+			want: Header{Type: "évol", Scope: "café", Subject: "ajoute le menu", Important: true},
+		},
+		{
+			txt:  "機能: ボタンを追加",
+			want: Header{Type: "機能", Subject: "ボタンを追加"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txt, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseHeader(tt.txt))
+		})
+	}
+}
+
+func TestHeaderParser(t *testing.T) {
+	ascii, err := NewHeaderParser(ASCIIHeaderSyntax)
+	assert.NoError(t, err)
+	assert.Equal(t, Header{Subject: "功能: 添加按钮"}, ascii.Parse("功能: 添加按钮"))
+	assert.Equal(t, Header{Type: "feat", Scope: "ui", Subject: "x"}, ascii.Parse("feat(ui): x"))
+
+	strict, err := NewHeaderParser(HeaderSyntax{TypeCharacters: `\p{Ll}`, ScopeCharacters: `\p{Ll}\p{Han}-`})
+	assert.NoError(t, err)
+	assert.Equal(t, Header{Type: "feat", Scope: "界面", Subject: "x"}, strict.Parse("feat(界面): x"))
+	assert.Equal(t, Header{Subject: "feat(ui web): x"}, strict.Parse("feat(ui web): x"))
+
+	_, err = NewHeaderParser(HeaderSyntax{TypeCharacters: `\p{Nope}`})
+	assert.Error(t, err)
+}
+
+func TestParseWithHeaderParser(t *testing.T) {
+	ascii, err := NewHeaderParser(ASCIIHeaderSyntax)
+	assert.NoError(t, err)
+
+	msg := ParseWith("功能: 添加按钮", ParseOptions{HeaderParser: ascii})
+	assert.Equal(t, Header{Subject: "功能: 添加按钮"}, msg.ParseHeader())
+	assert.Equal(t, Header{Type: "功能", Subject: "添加按钮"}, Parse("功能: 添加按钮").ParseHeader())
+
+	msg.Header = "feat(ui): x"
+	assert.Equal(t, Header{Type: "feat", Scope: "ui", Subject: "x"}, msg.Parsed().Header)
+
+	strict, err := NewHeaderParser(HeaderSyntax{TypeCharacters: `\p{Ll}`, ScopeCharacters: `\p{Ll}-`})
+	assert.NoError(t, err)
+
+	commits := []*Commit{
+		{Hash: "1111111", Message: ParseWith("feat(ui web): x", ParseOptions{HeaderParser: strict})},
+		{Hash: "2222222", Message: ParseWith("Revert \"feat(ui web): x\"\n\nThis reverts commit 1111111.", ParseOptions{HeaderParser: strict})},
+	}
+	assert.Empty(t, BuildChangelog(commits[:1], ChangelogOptions{}))
+	assert.Equal(t, Header{Subject: "feat(ui web): x"}, commits[1].Message.ParseRevert().Header)
+
+	// the messages nested in a merge, a squash or an autosquash follow the same syntax
+	merge := []*Commit{{Hash: "3333333", Message: ParseWith("Merge pull request #42 from org/ui\n\nfeat(ui web): x", ParseOptions{HeaderParser: strict})}}
+	assert.Empty(t, BuildChangelog(merge, ChangelogOptions{Merges: MergesExpand}))
+
+	squash := ParseWith("feat(ui): x (#7)\n\n* fix(ui web): y\n\n* fix(ui): z", ParseOptions{HeaderParser: strict}).ParseSquash()
+	if assert.Len(t, squash.Messages, 1) {
+		assert.Equal(t, "fix(ui): z", squash.Messages[0].Header)
+
+		squash.Messages[0].Header = "fix(ui web): z"
+		assert.Equal(t, Header{Subject: "fix(ui web): z"}, squash.Messages[0].ParseHeader())
+	}
+
+	assert.Equal(t, Header{Subject: "feat(ui web): x"}, ParseWith("fixup! feat(ui web): x", ParseOptions{HeaderParser: strict}).ParseAutosquash().Target)
+}
@@ -0,0 +1,288 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Inference is the type guessed for a message, see TypeRegistry.Infer
+type Inference struct {
+	Type string
+	// Confidence from 0 to 1, 1 when the header declares a registered type
+	Confidence float64
+	// Reasons are the clues of the guess, `verb "fix"` or `docs only paths`
+	Reasons []string
+}
+
+// TypeKeyword is a word suggesting a type in a header that declares none
+type TypeKeyword struct {
+	Word string
+	Type string
+	// Verb keywords count as the first word of the subject only
+	Verb   bool
+	Weight float64
+}
+
+// TypeKeywords are the clues of TypeRegistry.Infer, more can be appended.
+// Generic verbs, `Add` or `Update`, weigh less than the topics following them,
+// so `Add tests` is a test.
+var TypeKeywords = []TypeKeyword{
+	{Word: "fix", Type: "fix", Verb: true, Weight: 0.8},
+	{Word: "bugfix", Type: "fix", Verb: true, Weight: 0.8},
+	{Word: "hotfix", Type: "fix", Verb: true, Weight: 0.8},
+	{Word: "resolve", Type: "fix", Verb: true, Weight: 0.6},
+	{Word: "correct", Type: "fix", Verb: true, Weight: 0.6},
+	{Word: "repair", Type: "fix", Verb: true, Weight: 0.7},
+	{Word: "prevent", Type: "fix", Verb: true, Weight: 0.5},
+	{Word: "bug", Type: "fix", Weight: 0.4},
+	{Word: "crash", Type: "fix", Weight: 0.4},
+	{Word: "add", Type: "feat", Verb: true, Weight: 0.5},
+	{Word: "implement", Type: "feat", Verb: true, Weight: 0.7},
+	{Word: "introduce", Type: "feat", Verb: true, Weight: 0.7},
+	{Word: "support", Type: "feat", Verb: true, Weight: 0.6},
+	{Word: "allow", Type: "feat", Verb: true, Weight: 0.5},
+	{Word: "enable", Type: "feat", Verb: true, Weight: 0.5},
+	{Word: "new", Type: "feat", Verb: true, Weight: 0.5},
+	{Word: "feature", Type: "feat", Weight: 0.4},
+	{Word: "bump", Type: "build", Verb: true, Weight: 0.8},
+	{Word: "upgrade", Type: "build", Verb: true, Weight: 0.6},
+	{Word: "downgrade", Type: "build", Verb: true, Weight: 0.6},
+	{Word: "dependency", Type: "build", Weight: 0.5},
+	{Word: "dependencies", Type: "build", Weight: 0.5},
+	{Word: "deps", Type: "build", Weight: 0.5},
+	{Word: "refactor", Type: "refactor", Verb: true, Weight: 0.9},
+	{Word: "restructure", Type: "refactor", Verb: true, Weight: 0.8},
+	{Word: "rename", Type: "refactor", Verb: true, Weight: 0.6},
+	{Word: "move", Type: "refactor", Verb: true, Weight: 0.5},
+	{Word: "extract", Type: "refactor", Verb: true, Weight: 0.6},
+	{Word: "simplify", Type: "refactor", Verb: true, Weight: 0.6},
+	{Word: "cleanup", Type: "refactor", Verb: true, Weight: 0.5},
+	{Word: "clean", Type: "refactor", Verb: true, Weight: 0.4},
+	{Word: "optimize", Type: "perf", Verb: true, Weight: 0.8},
+	{Word: "speed", Type: "perf", Verb: true, Weight: 0.6},
+	{Word: "performance", Type: "perf", Weight: 0.6},
+	{Word: "faster", Type: "perf", Weight: 0.5},
+	{Word: "document", Type: "docs", Verb: true, Weight: 0.8},
+	{Word: "docs", Type: "docs", Weight: 0.7},
+	{Word: "doc", Type: "docs", Weight: 0.7},
+	{Word: "documentation", Type: "docs", Weight: 0.7},
+	{Word: "readme", Type: "docs", Weight: 0.7},
+	{Word: "typo", Type: "docs", Weight: 0.5},
+	{Word: "comment", Type: "docs", Weight: 0.4},
+	{Word: "test", Type: "test", Weight: 0.7},
+	{Word: "tests", Type: "test", Weight: 0.7},
+	{Word: "coverage", Type: "test", Weight: 0.5},
+	{Word: "ci", Type: "ci", Weight: 0.7},
+	{Word: "pipeline", Type: "ci", Weight: 0.5},
+	{Word: "workflow", Type: "ci", Weight: 0.5},
+	{Word: "format", Type: "style", Verb: true, Weight: 0.6},
+	{Word: "lint", Type: "style", Weight: 0.5},
+	{Word: "whitespace", Type: "style", Weight: 0.6},
+	{Word: "update", Type: "chore", Verb: true, Weight: 0.3},
+	{Word: "release", Type: "chore", Weight: 0.4},
+	{Word: "version", Type: "chore", Weight: 0.3},
+}
+
+// weight of the paths when all of them belong to the same type
+const pathsWeight = 0.9
+
+// the types of the paths, the first matching entry wins
+var pathTypes = []struct {
+	Type  string
+	Match func(p string) bool
+}{
+	{Type: "ci", Match: func(p string) bool {
+		return strings.HasPrefix(p, ".github/workflows/") || strings.HasPrefix(p, ".circleci/") || p == ".gitlab-ci.yml" || p == ".travis.yml"
+	}},
+	{Type: "test", Match: func(p string) bool {
+		base := path.Base(p)
+
+		return strings.HasSuffix(base, "_test.go") || strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+			strings.HasPrefix(base, "test_") || hasPathDirectory(p, "test", "tests", "testdata", "__tests__")
+	}},
+	{Type: "docs", Match: func(p string) bool {
+		base := strings.ToUpper(path.Base(p))
+		ext := strings.ToLower(path.Ext(p))
+
+		return ext == ".md" || ext == ".rst" || ext == ".adoc" || hasPathDirectory(p, "docs", "doc") ||
+			strings.HasPrefix(base, "README") || strings.HasPrefix(base, "CHANGELOG") || strings.HasPrefix(base, "LICENSE")
+	}},
+	{Type: "build", Match: func(p string) bool {
+		switch path.Base(p) {
+		case "go.mod", "go.sum", "go.work", "go.work.sum", "Makefile", "Dockerfile", "package.json", "package-lock.json",
+			"yarn.lock", "pnpm-lock.yaml", "Cargo.toml", "Cargo.lock", "requirements.txt", "pyproject.toml", "Gemfile", "Gemfile.lock":
+			return true
+		}
+
+		return false
+	}},
+}
+
+// hasPathDirectory reports whether one of the directories of the slash-separated path is named dir
+func hasPathDirectory(p string, dirs ...string) bool {
+	parts := strings.Split(p, "/")
+
+	for _, part := range parts[:len(parts)-1] {
+		for _, dir := range dirs {
+			if part == dir {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pathType returns the type of changes to the path, `test` for `a_test.go`,
+// or an empty string for source code
+func pathType(p string) string {
+	p = strings.TrimPrefix(path.Clean(strings.ReplaceAll(p, "\\", "/")), "./")
+
+	for _, t := range pathTypes {
+		if t.Match(p) {
+			return t.Type
+		}
+	}
+
+	return ""
+}
+
+// pathsType returns the type shared by all the paths, or an empty string
+func pathsType(paths []string) string {
+	common := ""
+
+	for i, p := range paths {
+		t := pathType(p)
+
+		if t == "" || (i != 0 && t != common) {
+			return ""
+		}
+
+		common = t
+	}
+
+	return common
+}
+
+// subjectWords returns the lower case words of the subject
+func subjectWords(subject string) []string {
+	return strings.FieldsFunc(strings.ToLower(subject), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// lookupTypeKeyword finds the keyword of the word or of its stem, `fixes` is `fix`
+func lookupTypeKeyword(word string, verb bool) *TypeKeyword {
+	for _, suffix := range []string{"", "s", "es", "ed", "d", "ing"} {
+		stem := strings.TrimSuffix(word, suffix)
+
+		if suffix != "" && (stem == word || len(stem) < 3) {
+			continue
+		}
+
+		for i := range TypeKeywords {
+			k := &TypeKeywords[i]
+
+			if k.Word == stem && (verb || !k.Verb) {
+				return k
+			}
+		}
+	}
+
+	return nil
+}
+
+// Infer returns the type of a message. The type declared by the header is
+// certain when the registry knows it, otherwise the type is guessed from the
+// shape of the message, merges and reverts, the words of the header and the
+// paths changed by the commit, which may be nil. The guessed types are types
+// of the registry, or `merge`. It returns nil when nothing hints at a type.
+func (r *TypeRegistry) Infer(m *Message, paths []string) *Inference {
+	header := m.ParseHeader()
+
+	if t := r.Lookup(header.Type); t != nil {
+		return &Inference{Type: t.Name, Confidence: 1, Reasons: []string{fmt.Sprintf("type %q", header.Type)}}
+	}
+
+	if merge := m.ParseMerge(); merge != nil {
+		return &Inference{Type: "merge", Confidence: 0.95, Reasons: []string{"merge commit"}}
+	}
+
+	if revert := m.ParseRevert(); revert != nil && r.Lookup("revert") != nil {
+		return &Inference{Type: "revert", Confidence: 0.9, Reasons: []string{"revert commit"}}
+	}
+
+	scores := make(map[string]float64)
+	reasons := make(map[string][]string)
+	topics := make(map[string]bool)
+
+	// the header without its declared type, `Update docs: x` counts as a whole
+	subject := strings.TrimSpace(strings.TrimPrefix(m.Header, header.Emoji))
+
+	for i, word := range subjectWords(subject) {
+		k := lookupTypeKeyword(word, i == 0)
+
+		if k == nil || r.Lookup(k.Type) == nil {
+			continue
+		}
+
+		// the topics of a type count once
+		if !k.Verb {
+			if topics[k.Type] {
+				continue
+			}
+
+			topics[k.Type] = true
+		}
+
+		scores[k.Type] += k.Weight
+
+		if k.Verb {
+			reasons[k.Type] = append(reasons[k.Type], fmt.Sprintf("verb %q", word))
+		} else {
+			reasons[k.Type] = append(reasons[k.Type], fmt.Sprintf("word %q", word))
+		}
+	}
+
+	if t := pathsType(paths); t != "" && r.Lookup(t) != nil {
+		scores[t] += pathsWeight
+		reasons[t] = append(reasons[t], t+" only paths")
+	}
+
+	if len(scores) == 0 {
+		return nil
+	}
+
+	types := make([]string, 0, len(scores))
+	total := 0.0
+
+	for t, score := range scores {
+		types = append(types, t)
+		total += score
+	}
+
+	// ties go to the order of the registry
+	sort.Slice(types, func(i, j int) bool {
+		if scores[types[i]] != scores[types[j]] {
+			return scores[types[i]] > scores[types[j]]
+		}
+
+		return r.Index(types[i]) < r.Index(types[j])
+	})
+
+	best := types[0]
+	// strong clues are trusted unless other types compete with them
+	confidence := scores[best]
+	if confidence > 0.95 {
+		confidence = 0.95
+	}
+
+	return &Inference{
+		Type:       r.Canonical(best),
+		Confidence: confidence * scores[best] / total,
+		Reasons:    reasons[best],
+	}
+}
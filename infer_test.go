@@ -0,0 +1,84 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeRegistryInfer(t *testing.T) {
+	tests := []struct {
+		message string
+		paths   []string
+		want    string
+		reasons []string
+	}{
+		{"feature(api): add search", nil, "feat", []string{`type "feature"`}},
+		{"Fix login redirect", nil, "fix", []string{`verb "fix"`}},
+		{"Fixed crash on startup", nil, "fix", []string{`verb "fixed"`, `word "crash"`}},
+		{"Add dark mode", nil, "feat", []string{`verb "add"`}},
+		{"Add tests for the parser", nil, "test", []string{`word "tests"`}},
+		{"Bump golang.org/x/text from 0.3.6 to 0.3.7", nil, "build", []string{`verb "bump"`}},
+		{"Refactor the scanner", nil, "refactor", []string{`verb "refactor"`}},
+		{"Update docs: install", nil, "docs", []string{`word "docs"`}},
+		{"Update", []string{"README.md", "docs/install.md"}, "docs", []string{"docs only paths"}},
+		{"Fix typo", []string{"README.md"}, "docs", []string{`word "typo"`, "docs only paths"}},
+		{"Improve things", []string{"parser_test.go", "testdata/a.txt"}, "test", []string{"test only paths"}},
+		{"Merge branch 'main' into dev", nil, "merge", []string{"merge commit"}},
+		{"Reapply \"Add dark mode\"", nil, "revert", []string{"revert commit"}},
+	}
+
+	for _, test := range tests {
+		inference := DefaultTypeRegistry.Infer(Parse(test.message), test.paths)
+
+		if assert.NotNil(t, inference, test.message) {
+			assert.Equal(t, test.want, inference.Type, test.message)
+			assert.Equal(t, test.reasons, inference.Reasons, test.message)
+			assert.True(t, inference.Confidence > 0 && inference.Confidence <= 1, test.message)
+		}
+	}
+
+	assert.Nil(t, DefaultTypeRegistry.Infer(Parse("Whatever"), []string{"main.go"}))
+	assert.Nil(t, DefaultTypeRegistry.Infer(Parse(""), nil))
+}
+
+func TestTypeRegistryInferConfidence(t *testing.T) {
+	infer := func(message string, paths ...string) float64 {
+		return DefaultTypeRegistry.Infer(Parse(message), paths).Confidence
+	}
+
+	assert.Equal(t, 1.0, infer("fix: x"))
+	assert.Greater(t, infer("Fix login"), infer("Update login"))
+	// competing clues lower the confidence
+	assert.Greater(t, infer("Fix login"), infer("Fix typo in the docs"))
+	// the paths confirm the words
+	assert.Greater(t, infer("Document the API", "docs/api.md"), infer("Document the API"))
+}
+
+func TestTypeRegistryInferCustom(t *testing.T) {
+	registry := &TypeRegistry{Types: []TypeDefinition{{Name: "bugfix"}, {Name: "feature"}}}
+
+	// the guesses are types of the registry
+	assert.Nil(t, registry.Infer(Parse("Fix login"), nil))
+	assert.Equal(t, "bugfix", registry.Infer(Parse("bugfix: x"), nil).Type)
+}
+
+func TestPathType(t *testing.T) {
+	for p, want := range map[string]string{
+		"main.go":                    "",
+		"pkg/parser.go":              "",
+		"pkg/parser_test.go":         "test",
+		"src/app.spec.ts":            "test",
+		"./testdata/input.txt":       "test",
+		"docs/guide/index.html":      "docs",
+		"README":                     "docs",
+		"pkg/README.md":              "docs",
+		"go.sum":                     "build",
+		"tools/go.mod":               "build",
+		".github/workflows/ci.yml":   "ci",
+		".github/ISSUE_TEMPLATE.md":  "docs",
+		"internal\\docs\\install.md": "docs",
+	} {
+		assert.Equal(t, want, pathType(p), p)
+	}
+}
@@ -0,0 +1,30 @@
+package conventionalcommitparser
+
+// LintProblem is a violation reported by a LintRule
+type LintProblem struct {
+	Rule    string
+	Message string
+}
+
+func (p LintProblem) String() string {
+	return p.Rule + ": " + p.Message
+}
+
+// LintRule checks a commit and returns a message for each violation
+type LintRule struct {
+	Name  string
+	Check func(c *Commit) []string
+}
+
+// Lint runs the rules against the commit
+func Lint(c *Commit, rules ...LintRule) []LintProblem {
+	problems := make([]LintProblem, 0)
+
+	for _, rule := range rules {
+		for _, message := range rule.Check(c) {
+			problems = append(problems, LintProblem{Rule: rule.Name, Message: message})
+		}
+	}
+
+	return problems
+}
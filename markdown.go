@@ -0,0 +1,182 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BlockKind is the kind of a markdown block
+type BlockKind string
+
+const (
+	BlockParagraph BlockKind = "paragraph"
+	BlockList      BlockKind = "list"
+	BlockCode      BlockKind = "code"
+	BlockQuote     BlockKind = "quote"
+)
+
+// Block is a markdown block of the body
+type Block struct {
+	Kind BlockKind
+	// Text is the content without the markup: without the fences or the
+	// indentation of code, the `>` of quotes and the bullets of lists
+	Text string
+	// Language of a fenced code block, ```go
+	Language string
+	// Items of a list
+	Items []string
+}
+
+var (
+	fencePattern        = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+	quotePattern        = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	listItemPattern     = regexp.MustCompile(`^ {0,3}([*+-]|\d+[.)])\s+(.*)$`)
+	indentedCodePattern = regexp.MustCompile(`^(    |\t)(.*)$`)
+)
+
+// closesFence reports whether the line closes the fence opened by open, ``` or ~~~~
+func closesFence(line string, open string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return len(trimmed) >= len(open) && strings.Trim(trimmed, open[:1]) == "" && len(line)-len(strings.TrimLeft(line, " ")) <= 3
+}
+
+// markdownCodeLines reports the lines of fenced code blocks, fences included.
+// An unclosed fence runs to the end of the text.
+func markdownCodeLines(lines []string) []bool {
+	code := make([]bool, len(lines))
+	fence := ""
+
+	for i, line := range lines {
+		if fence != "" {
+			code[i] = true
+
+			if closesFence(line, fence) {
+				fence = ""
+			}
+
+			continue
+		}
+
+		if fence = fenceMarker(line); fence != "" {
+			code[i] = true
+		}
+	}
+
+	return code
+}
+
+// ParseBlocks splits markdown text into paragraphs, lists, code blocks and quotes
+func ParseBlocks(txt string) []Block {
+	blocks := make([]Block, 0)
+	lines := splitToLines(txt)
+	current := (*Block)(nil)
+	content := []string(nil)
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+
+		switch current.Kind {
+		case BlockList:
+			current.Text = strings.Join(current.Items, "\n")
+		case BlockCode:
+			// indented code blocks keep the blank lines between their lines only
+			for len(content) != 0 && isEmptyLine(content[len(content)-1]) {
+				content = content[:len(content)-1]
+			}
+			current.Text = strings.Join(content, "\n")
+		default:
+			current.Text = strings.Join(content, "\n")
+		}
+
+		blocks = append(blocks, *current)
+		current = nil
+		content = nil
+	}
+
+	start := func(kind BlockKind) {
+		flush()
+		current = &Block{Kind: kind}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if matcher := fencePattern.FindStringSubmatch(line); matcher != nil {
+			start(BlockCode)
+			current.Language = matcher[2]
+
+			for i++; i < len(lines) && !closesFence(lines[i], matcher[1]); i++ {
+				content = append(content, lines[i])
+			}
+
+			// keep the blank lines of fenced code
+			current.Text = strings.Join(content, "\n")
+			blocks = append(blocks, *current)
+			current = nil
+			content = nil
+			continue
+		}
+
+		if isEmptyLine(line) {
+			if current != nil && current.Kind == BlockCode {
+				content = append(content, "")
+				continue
+			}
+
+			flush()
+			continue
+		}
+
+		if matcher := indentedCodePattern.FindStringSubmatch(line); matcher != nil && (current == nil || current.Kind == BlockCode) {
+			if current == nil {
+				start(BlockCode)
+			}
+
+			content = append(content, matcher[2])
+			continue
+		}
+
+		if matcher := quotePattern.FindStringSubmatch(line); matcher != nil {
+			if current == nil || current.Kind != BlockQuote {
+				start(BlockQuote)
+			}
+
+			content = append(content, matcher[1])
+			continue
+		}
+
+		if matcher := listItemPattern.FindStringSubmatch(line); matcher != nil {
+			if current == nil || current.Kind != BlockList {
+				start(BlockList)
+			}
+
+			current.Items = append(current.Items, matcher[2])
+			continue
+		}
+
+		switch {
+		case current == nil || current.Kind == BlockCode:
+			start(BlockParagraph)
+			content = append(content, line)
+		case current.Kind == BlockList:
+			// continuation of the last item
+			last := len(current.Items) - 1
+			current.Items[last] += "\n" + strings.TrimSpace(line)
+		default:
+			// paragraph or lazy continuation of a quote
+			content = append(content, line)
+		}
+	}
+
+	flush()
+
+	return blocks
+}
+
+// BodyBlocks returns the body as markdown blocks
+func (m *Message) BodyBlocks() []Block {
+	return ParseBlocks(m.Body)
+}
@@ -0,0 +1,78 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMarkdownBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		wantBody   string
+		wantFooter []string
+	}{
+		{
+			name:       "fenced yaml",
+			message:    "feat: add config\n\nAn example config\n\n```yaml\nname: api\n\nport: 8080\n```\n\nRefs: #12",
+			wantBody:   "An example config\n\n```yaml\nname: api\n\nport: 8080\n```",
+			wantFooter: []string{"Refs: #12"},
+		},
+		{
+			name:       "unclosed fence",
+			message:    "feat: add config\n\n~~~\nname: api\n\nport: 8080",
+			wantBody:   "~~~\nname: api\n\nport: 8080",
+			wantFooter: []string{},
+		},
+		{
+			name:       "indented code",
+			message:    "feat: add config\n\nAn example config\n\n    name: api\n\n    port: 8080\n\nRefs: #12",
+			wantBody:   "An example config\n\n    name: api\n\n    port: 8080",
+			wantFooter: []string{"Refs: #12"},
+		},
+		{
+			name:       "quote",
+			message:    "fix: typo\n\n> Note: the old name is kept\n\n> Warning: deprecated",
+			wantBody:   "> Note: the old name is kept\n\n> Warning: deprecated",
+			wantFooter: []string{},
+		},
+		{
+			name:       "code in a footer",
+			message:    "feat!: rename\n\nBREAKING CHANGE: the config is renamed\n\n```yaml\nname: api\n```\n\nReviewed-by: Z",
+			wantBody:   "",
+			wantFooter: []string{"BREAKING CHANGE: the config is renamed\n\n```yaml\nname: api\n```", "Reviewed-by: Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := Parse(tt.message)
+
+			assert.Equal(t, tt.wantBody, msg.Body)
+			assert.Equal(t, tt.wantFooter, msg.Footer)
+		})
+	}
+}
+
+func TestParseBlocks(t *testing.T) {
+	txt := "Intro line\nsecond line\n\n" +
+		"* first item\n  continued\n- second item\n\n" +
+		"1. one\n2) two\n\n" +
+		"```go\nfunc main() {}\n\n// end\n```\n\n" +
+		"    indented\n\n    code\n\n" +
+		"> quoted\nlazy\n>\n> more\n\n" +
+		"~~~~\n```\n~~~~"
+
+	assert.Equal(t, []Block{
+		{Kind: BlockParagraph, Text: "Intro line\nsecond line"},
+		{Kind: BlockList, Text: "first item\ncontinued\nsecond item", Items: []string{"first item\ncontinued", "second item"}},
+		{Kind: BlockList, Text: "one\ntwo", Items: []string{"one", "two"}},
+		{Kind: BlockCode, Text: "func main() {}\n\n// end", Language: "go"},
+		{Kind: BlockCode, Text: "indented\n\ncode"},
+		{Kind: BlockQuote, Text: "quoted\nlazy\n\nmore"},
+		{Kind: BlockCode, Text: "```"},
+	}, ParseBlocks(txt))
+
+	assert.Equal(t, []Block{}, ParseBlocks(""))
+	assert.Equal(t, []Block{{Kind: BlockParagraph, Text: "body"}}, Parse("feat: x\n\nbody\n\nRefs: #1").BodyBlocks())
+}
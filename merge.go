@@ -0,0 +1,144 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"strconv"
+)
+
+type MergeKind string
+
+const (
+	MergeKindPullRequest          MergeKind = "pull-request"
+	MergeKindMergeRequest         MergeKind = "merge-request"
+	MergeKindBranch               MergeKind = "branch"
+	MergeKindRemoteTrackingBranch MergeKind = "remote-tracking-branch"
+	MergeKindTag                  MergeKind = "tag"
+)
+
+// Merge describes a merge commit
+type Merge struct {
+	Kind     MergeKind
+	Provider string
+	// Number of the pull request or merge request, 0 when unknown
+	Number       int
+	SourceBranch string
+	TargetBranch string
+	SourceRepo   string
+}
+
+// MergePattern recognizes the merge commits of a hosting provider.
+// The named groups `number`, `source`, `target` and `repo` of the header
+// and body patterns fill the Merge. When Body is set it must match the
+// message after the header too.
+type MergePattern struct {
+	Provider string
+	Kind     MergeKind
+	Header   *regexp.Regexp
+	Body     *regexp.Regexp
+}
+
+var DefaultMergePatterns = []MergePattern{
+	{
+		Provider: "github",
+		Kind:     MergeKindPullRequest,
+		Header:   regexp.MustCompile(`^Merge pull request #(?P<number>\d+) from (?P<repo>[^/\s]+)/(?P<source>\S+)$`),
+	},
+	{
+		Provider: "gitlab",
+		Kind:     MergeKindMergeRequest,
+		Header:   regexp.MustCompile(`^Merge branch '(?P<source>[^']+)' into '(?P<target>[^']+)'$`),
+		Body:     regexp.MustCompile(`(?m)^See merge request (?P<repo>\S+)!(?P<number>\d+)\s*$`),
+	},
+	{
+		Provider: "bitbucket",
+		Kind:     MergeKindPullRequest,
+		Header:   regexp.MustCompile(`^Merged in (?P<source>\S+) \(pull request #(?P<number>\d+)\)$`),
+	},
+	{
+		Provider: "azure",
+		Kind:     MergeKindPullRequest,
+		Header:   regexp.MustCompile(`^Merged PR (?P<number>\d+):`),
+	},
+	{
+		Provider: "git",
+		Kind:     MergeKindRemoteTrackingBranch,
+		Header:   regexp.MustCompile(`^Merge remote-tracking branch '(?P<source>[^']+)'(?: into (?P<target>\S+))?$`),
+	},
+	{
+		Provider: "git",
+		Kind:     MergeKindBranch,
+		Header:   regexp.MustCompile(`^Merge branch '(?P<source>[^']+)'(?: of (?P<repo>\S+))?(?: into '?(?P<target>[^']+?)'?)?$`),
+	},
+	{
+		Provider: "git",
+		Kind:     MergeKindTag,
+		Header:   regexp.MustCompile(`^Merge tag '(?P<source>[^']+)'(?: of (?P<repo>\S+))?(?: into '?(?P<target>[^']+?)'?)?$`),
+	},
+}
+
+// Reference returns `#42` for pull requests and `!42` for merge requests,
+// or an empty string when the number is unknown
+func (m *Merge) Reference() string {
+	if m.Number == 0 {
+		return ""
+	}
+
+	if m.Kind == MergeKindMergeRequest {
+		return "!" + strconv.Itoa(m.Number)
+	}
+
+	return "#" + strconv.Itoa(m.Number)
+}
+
+func (m *Merge) fill(pattern *regexp.Regexp, matches []string) {
+	for i, name := range pattern.SubexpNames() {
+		if matches[i] == "" {
+			continue
+		}
+
+		switch name {
+		case "number":
+			m.Number, _ = strconv.Atoi(matches[i])
+		case "source":
+			m.SourceBranch = matches[i]
+		case "target":
+			m.TargetBranch = matches[i]
+		case "repo":
+			m.SourceRepo = matches[i]
+		}
+	}
+}
+
+// ParseMerge returns the merge described by the first matching pattern, or nil
+func ParseMerge(m *Message, patterns []MergePattern) *Merge {
+	for _, p := range patterns {
+		headerMatches := p.Header.FindStringSubmatch(m.Header)
+		if headerMatches == nil {
+			continue
+		}
+
+		var bodyMatches []string
+
+		if p.Body != nil {
+			if bodyMatches = p.Body.FindStringSubmatch(m.afterHeader()); bodyMatches == nil {
+				continue
+			}
+		}
+
+		merge := Merge{Kind: p.Kind, Provider: p.Provider}
+		merge.fill(p.Header, headerMatches)
+
+		if bodyMatches != nil {
+			merge.fill(p.Body, bodyMatches)
+		}
+
+		return &merge
+	}
+
+	return nil
+}
+
+// ParseMerge returns the merge recognized with DefaultMergePatterns, or nil
+func (m *Message) ParseMerge() *Merge {
+	return ParseMerge(m, DefaultMergePatterns)
+}
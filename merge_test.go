@@ -0,0 +1,93 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *Merge
+	}{
+		{
+			name:    "not a merge",
+			message: "feat: add search",
+			want:    nil,
+		},
+		{
+			name:    "github pull request",
+			message: "Merge pull request #42 from org/feature/x\n\nfeat: add search",
+			want:    &Merge{Kind: MergeKindPullRequest, Provider: "github", Number: 42, SourceBranch: "feature/x", SourceRepo: "org"},
+		},
+		{
+			name:    "gitlab merge request",
+			message: "Merge branch 'feature-x' into 'main'\n\nfeat: add search\n\nSee merge request group/proj!123",
+			want:    &Merge{Kind: MergeKindMergeRequest, Provider: "gitlab", Number: 123, SourceBranch: "feature-x", TargetBranch: "main", SourceRepo: "group/proj"},
+		},
+		{
+			name:    "bitbucket pull request",
+			message: "Merged in feature-x (pull request #7)",
+			want:    &Merge{Kind: MergeKindPullRequest, Provider: "bitbucket", Number: 7, SourceBranch: "feature-x"},
+		},
+		{
+			name:    "azure pull request",
+			message: "Merged PR 9: feat: add search",
+			want:    &Merge{Kind: MergeKindPullRequest, Provider: "azure", Number: 9},
+		},
+		{
+			name:    "branch",
+			message: "Merge branch 'main' into dev",
+			want:    &Merge{Kind: MergeKindBranch, Provider: "git", SourceBranch: "main", TargetBranch: "dev"},
+		},
+		{
+			name:    "branch into current",
+			message: "Merge branch 'main'",
+			want:    &Merge{Kind: MergeKindBranch, Provider: "git", SourceBranch: "main"},
+		},
+		{
+			name:    "branch of a remote",
+			message: "Merge branch 'main' of github.com:org/repo into dev",
+			want:    &Merge{Kind: MergeKindBranch, Provider: "git", SourceBranch: "main", SourceRepo: "github.com:org/repo", TargetBranch: "dev"},
+		},
+		{
+			name:    "remote-tracking branch",
+			message: "Merge remote-tracking branch 'origin/main' into dev",
+			want:    &Merge{Kind: MergeKindRemoteTrackingBranch, Provider: "git", SourceBranch: "origin/main", TargetBranch: "dev"},
+		},
+		{
+			name:    "tag",
+			message: "Merge tag 'v1.0.0'",
+			want:    &Merge{Kind: MergeKindTag, Provider: "git", SourceBranch: "v1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.message).ParseMerge())
+		})
+	}
+}
+
+func TestParseMergeCustomPatterns(t *testing.T) {
+	patterns := []MergePattern{
+		{
+			Provider: "gitea",
+			Kind:     MergeKindPullRequest,
+			Header:   regexp.MustCompile(`^Merge pull request '.*' \(#(?P<number>\d+)\) from (?P<source>\S+) into (?P<target>\S+)$`),
+		},
+	}
+
+	msg := Parse("Merge pull request 'add search' (#5) from feature-x into main")
+
+	assert.Equal(t, &Merge{Kind: MergeKindPullRequest, Provider: "gitea", Number: 5, SourceBranch: "feature-x", TargetBranch: "main"}, ParseMerge(msg, patterns))
+	assert.Nil(t, ParseMerge(Parse("Merge branch 'main'"), patterns))
+}
+
+func TestMergeReference(t *testing.T) {
+	assert.Equal(t, "#42", (&Merge{Kind: MergeKindPullRequest, Number: 42}).Reference())
+	assert.Equal(t, "!42", (&Merge{Kind: MergeKindMergeRequest, Number: 42}).Reference())
+	assert.Equal(t, "", (&Merge{Kind: MergeKindBranch}).Reference())
+}
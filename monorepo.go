@@ -0,0 +1,214 @@
+package conventionalcommitparser
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DiscoverGoModules walks the directory for go.mod files, as the go command
+// does it skips the vendor and testdata directories and the ones starting
+// with `.` or `_`. The modules are sorted by directory, the root first.
+func DiscoverGoModules(dir string) ([]GoModule, error) {
+	modules := make([]GoModule, 0)
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		name := d.Name()
+		if p != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		module, err := readGoModule(dir, filepath.ToSlash(rel))
+		if err == nil {
+			modules = append(modules, module)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("discover go modules: %w", err)
+	}
+
+	return modules, nil
+}
+
+// TagPrefix returns the prefix of the tags of the module: its directory
+// followed by a slash, without the major version subdirectory of
+// `mod/v2/go.mod`, and empty for the root module
+func (m GoModule) TagPrefix() string {
+	dir := m.Dir
+
+	if major := ModulePathMajor(m.Path); major >= 2 && path.Base(dir) == fmt.Sprintf("v%d", major) {
+		dir = path.Dir(dir)
+	}
+
+	if dir == "." {
+		return ""
+	}
+
+	return dir + "/"
+}
+
+// Tag returns the git tag of a version of the module, `tools/cli/v1.4.0`
+func (m GoModule) Tag(v Version) string {
+	return m.TagPrefix() + v.String()
+}
+
+// LatestVersion returns the highest version of the module among the tags,
+// the tags of other modules and the invalid ones are ignored. It returns
+// false when the module has no version yet.
+func (m GoModule) LatestVersion(tags []string) (Version, bool) {
+	latest := Version{}
+	found := false
+	prefix := m.TagPrefix()
+
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) || strings.Contains(tag[len(prefix):], "/") {
+			continue
+		}
+
+		txt := tag[len(prefix):]
+		v, err := ParseVersion(txt)
+
+		// the tags of the other majors belong to the other module paths
+		if err != nil || !strings.HasPrefix(txt, "v") || len(CheckModuleVersion(m.Path, v)) != 0 {
+			continue
+		}
+
+		if !found || v.Compare(latest) > 0 {
+			latest = v
+			found = true
+		}
+	}
+
+	return latest, found
+}
+
+// scopeOfModule reports whether a scope of the header names the module:
+// its directory, `tools/cli`, or the last element of its directory, `cli`
+func scopeOfModule(header Header, m GoModule) bool {
+	if m.Dir == "." {
+		return false
+	}
+
+	dir := strings.TrimSuffix(m.TagPrefix(), "/")
+
+	for _, scope := range header.HierarchicalScopes() {
+		if s := scope.String(); strings.EqualFold(s, dir) || strings.EqualFold(s, path.Base(dir)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AttributeCommits returns the commits changing each module, in the order
+// of the modules. A commit changes the modules containing its paths, each
+// path belongs to the deepest module. A commit without known paths changes
+// the modules named by its scopes, `fix(cli):` changes tools/cli, or
+// every module when no scope names one.
+func AttributeCommits(modules []GoModule, commits []*Commit) [][]*Commit {
+	attributed := make([][]*Commit, len(modules))
+
+	for i := range attributed {
+		attributed[i] = make([]*Commit, 0)
+	}
+
+	for _, c := range commits {
+		changed := make([]bool, len(modules))
+		found := false
+
+		if len(c.Paths) != 0 {
+			for _, p := range c.Paths {
+				if i := moduleOfPath(modules, p); i != -1 {
+					changed[i] = true
+				}
+			}
+
+			found = true
+		} else {
+			header := c.Message.ParseHeader()
+
+			for i, m := range modules {
+				changed[i] = scopeOfModule(header, m)
+				found = found || changed[i]
+			}
+		}
+
+		for i := range modules {
+			if changed[i] || !found {
+				attributed[i] = append(attributed[i], c)
+			}
+		}
+	}
+
+	return attributed
+}
+
+// ModuleRelease is the next release of a module, see PlanModuleReleases
+type ModuleRelease struct {
+	Module GoModule
+	// Commits changing the module, see AttributeCommits
+	Commits []*Commit
+	Bump    Bump
+	// Current version, from the tags, v0.0.0 when the module has none
+	Current Version
+	Next    Version
+	// Tag of the next version, empty when nothing is to be released
+	Tag string
+	// Problems of the next version, see CheckModuleVersion
+	Problems []string
+
+	// opts of the plan, the changelog lists the commits of the bump
+	opts ChangelogOptions
+}
+
+// PlanModuleReleases computes the next release of each module from its
+// latest tag and the commits since, the commits are attributed to the
+// modules with AttributeCommits. The bump follows the types of the options
+// and the same commits as the changelog, see ChangelogOptions.ExpandSquashes
+// and ChangelogOptions.CancelReverts.
+func PlanModuleReleases(modules []GoModule, tags []string, commits []*Commit, opts ChangelogOptions) []ModuleRelease {
+	types := opts.types()
+	releases := make([]ModuleRelease, 0, len(modules))
+
+	for i, attributed := range AttributeCommits(modules, commits) {
+		release := ModuleRelease{Module: modules[i], Commits: attributed, Bump: types.BumpCommits(opts.history(attributed)), opts: opts}
+		current, found := modules[i].LatestVersion(tags)
+		release.Current = current
+		release.Next, release.Problems = nextModuleVersion(modules[i], current, found, release.Bump)
+
+		if release.Bump != BumpNone {
+			release.Tag = modules[i].Tag(release.Next)
+		}
+
+		releases = append(releases, release)
+	}
+
+	return releases
+}
+
+// Changelog builds the changelog of the commits of the release with the
+// options of the plan, see BuildChangelog
+func (r ModuleRelease) Changelog() []ChangelogSection {
+	return BuildChangelog(r.Commits, r.opts)
+}
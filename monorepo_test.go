@@ -0,0 +1,144 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverGoModules(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"go.mod":                  "module example.com/repo\n",
+		"parser.go":               "package repo\n",
+		"tools/cli/go.mod":        "module example.com/repo/tools/cli\n",
+		"tools/cli/v2/go.mod":     "module example.com/repo/tools/cli/v2\n",
+		"lib/go.mod":              "module example.com/repo/lib\n",
+		"lib/testdata/m/go.mod":   "module example.com/testdata\n",
+		"vendor/x/go.mod":         "module example.com/x\n",
+		".cache/go.mod":           "module example.com/cache\n",
+		"_examples/go.mod":        "module example.com/examples\n",
+		"tools/scripts/README.md": "scripts\n",
+	})
+
+	modules, err := DiscoverGoModules(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []GoModule{
+		{Path: "example.com/repo", Dir: "."},
+		{Path: "example.com/repo/lib", Dir: "lib"},
+		{Path: "example.com/repo/tools/cli", Dir: "tools/cli"},
+		{Path: "example.com/repo/tools/cli/v2", Dir: "tools/cli/v2"},
+	}, modules)
+
+	_, err = DiscoverGoModules(writeFiles(t, map[string]string{"a/go.mod": "go 1.17\n"}))
+	assert.EqualError(t, err, "discover go modules: a/go.mod: no module directive")
+}
+
+func TestGoModuleTag(t *testing.T) {
+	v := Version{Major: 1, Minor: 4}
+
+	assert.Equal(t, "v1.4.0", GoModule{Path: "example.com/repo", Dir: "."}.Tag(v))
+	assert.Equal(t, "tools/cli/v1.4.0", GoModule{Path: "example.com/repo/tools/cli", Dir: "tools/cli"}.Tag(v))
+	// major version subdirectories share the tags of their parent directory
+	assert.Equal(t, "tools/cli/v2.0.0", GoModule{Path: "example.com/repo/tools/cli/v2", Dir: "tools/cli/v2"}.Tag(Version{Major: 2}))
+	assert.Equal(t, "v2.0.0", GoModule{Path: "example.com/repo/v2", Dir: "v2"}.Tag(Version{Major: 2}))
+}
+
+func TestGoModuleLatestVersion(t *testing.T) {
+	tags := []string{"v1.2.0", "v1.10.0", "v1.11.0-rc.1", "v2.0.0", "1.12.0", "tools/cli/v0.3.0", "tools/cli/v2.1.0", "tools/cli/vx", "release-1"}
+
+	latest, found := GoModule{Path: "example.com/repo", Dir: "."}.LatestVersion(tags)
+	assert.True(t, found)
+	assert.Equal(t, Version{Major: 1, Minor: 11, Prerelease: "rc.1"}, latest)
+
+	latest, _ = GoModule{Path: "example.com/repo/v2", Dir: "."}.LatestVersion(tags)
+	assert.Equal(t, Version{Major: 2}, latest)
+
+	latest, _ = GoModule{Path: "example.com/repo/tools/cli", Dir: "tools/cli"}.LatestVersion(tags)
+	assert.Equal(t, Version{Minor: 3}, latest)
+
+	_, found = GoModule{Path: "example.com/repo/lib", Dir: "lib"}.LatestVersion(tags)
+	assert.False(t, found)
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{"v1.0.0-alpha", "v1.0.0-alpha.1", "v1.0.0-alpha.beta", "v1.0.0-beta", "v1.0.0-beta.2", "v1.0.0-beta.11", "v1.0.0-rc.1", "v1.0.0", "v1.0.1", "v1.1.0", "v2.0.0"}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := ParseVersion(ordered[i])
+			b, _ := ParseVersion(ordered[j])
+			assert.Equal(t, sign(i-j), a.Compare(b), "%s %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestPlanModuleReleases(t *testing.T) {
+	modules := []GoModule{
+		{Path: "example.com/repo", Dir: "."},
+		{Path: "example.com/repo/tools/cli", Dir: "tools/cli"},
+		{Path: "example.com/repo/lib/v2", Dir: "lib"},
+	}
+	tags := []string{"v1.3.0", "tools/cli/v1.4.0"}
+	commits := []*Commit{
+		{Hash: "1111111", Message: Parse("fix(cli): flags")},
+		{Hash: "2222222", Message: Parse("feat: search"), Paths: []string{"search.go", "tools/cli/search.go"}},
+		{Hash: "3333333", Message: Parse("feat(lib)!: drop v1"), Paths: []string{"lib/lib.go"}},
+		{Hash: "4444444", Message: Parse("docs: readme")},
+	}
+
+	releases := PlanModuleReleases(modules, tags, commits, ChangelogOptions{})
+
+	assert.Equal(t, []ModuleRelease{
+		{
+			Module:   modules[0],
+			Commits:  []*Commit{commits[1], commits[3]},
+			Bump:     BumpMinor,
+			Current:  Version{Major: 1, Minor: 3},
+			Next:     Version{Major: 1, Minor: 4},
+			Tag:      "v1.4.0",
+			Problems: []string{},
+		},
+		{
+			Module:   modules[1],
+			Commits:  []*Commit{commits[0], commits[1], commits[3]},
+			Bump:     BumpMinor,
+			Current:  Version{Major: 1, Minor: 4},
+			Next:     Version{Major: 1, Minor: 5},
+			Tag:      "tools/cli/v1.5.0",
+			Problems: []string{},
+		},
+		{
+			Module:   modules[2],
+			Commits:  []*Commit{commits[2], commits[3]},
+			Bump:     BumpMajor,
+			Next:     Version{Major: 2},
+			Tag:      "lib/v2.0.0",
+			Problems: []string{},
+		},
+	}, releases)
+
+	sections := releases[1].Changelog()
+	if assert.Len(t, sections, 2) {
+		assert.Equal(t, "Features", sections[0].Title)
+		assert.Equal(t, "Bug Fixes", sections[1].Title)
+		assert.Equal(t, "flags", sections[1].Entries[0].Description)
+	}
+
+	// nothing to release
+	releases = PlanModuleReleases(modules[:1], tags, commits[3:], ChangelogOptions{})
+	assert.Equal(t, "", releases[0].Tag)
+	assert.Equal(t, Version{Major: 1, Minor: 3}, releases[0].Next)
+
+	// reverted commits bump nothing, as they are left out of the changelog
+	reverted := []*Commit{
+		{Hash: "5555555", Message: Parse("feat: search")},
+		{Hash: "6666666", Message: Parse("Revert \"feat: search\"\n\nThis reverts commit 5555555.")},
+	}
+	releases = PlanModuleReleases(modules[:1], tags, reverted, ChangelogOptions{CancelReverts: true})
+	assert.Equal(t, BumpNone, releases[0].Bump)
+	assert.Equal(t, "", releases[0].Tag)
+	assert.Equal(t, []ChangelogSection{}, releases[0].Changelog())
+
+	releases = PlanModuleReleases(modules[:1], tags, reverted, ChangelogOptions{})
+	assert.Equal(t, BumpMinor, releases[0].Bump)
+}
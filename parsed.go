@@ -0,0 +1,136 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Reference is an issue referenced by a footer, `Closes #12` or `Refs: org/repo#12`
+type Reference struct {
+	// Action is the token of the footer, `Closes`
+	Action string
+	// Issue as written, `#12` or `org/repo#12`
+	Issue string
+}
+
+// Parsed is the structured representation of a message, see Message.Parsed
+type Parsed struct {
+	Header Header
+	// Footers are the footers of the message, followed by the revert
+	// footer of revert commits, see Message.ParseFooter
+	Footers []Footer
+	// BreakingChanges are the BREAKING CHANGE footers, or a BREAKING CHANGE
+	// footer with the subject when only the header is marked with `!`
+	BreakingChanges []Footer
+	References      []Reference
+
+	// trailers of the footers, see Message.Trailers
+	trailers []trailerEntry
+	// the fields of the message the representation was computed from
+	fields messageFields
+}
+
+// messageFields are the fields of a message at some point
+type messageFields struct {
+	header string
+	body   string
+	footer []string
+}
+
+func newMessageFields(m *Message) messageFields {
+	return messageFields{
+		header: m.Header,
+		body:   m.Body,
+		// a copy, the footers of the message can be edited in place
+		footer: append(make([]string, 0, len(m.Footer)), m.Footer...),
+	}
+}
+
+// equal reports whether the fields of the message are still the same
+func (f messageFields) equal(m *Message) bool {
+	if f.header != m.Header || f.body != m.Body || len(f.footer) != len(m.Footer) {
+		return false
+	}
+
+	for i := range f.footer {
+		if f.footer[i] != m.Footer[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+var referencePattern = regexp.MustCompile(`^(?:[\w.-]+/[\w.-]+)?#\d+$`)
+
+func parseReferences(f Footer) []Reference {
+	references := make([]Reference, 0)
+
+	for _, issue := range strings.FieldsFunc(f.Title, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		if referencePattern.MatchString(issue) {
+			references = append(references, Reference{Action: f.Tag, Issue: issue})
+		}
+	}
+
+	return references
+}
+
+func newParsed(m *Message) *Parsed {
+	p := &Parsed{
+		Header:          m.headerParser().Parse(m.Header),
+		Footers:         make([]Footer, 0, len(m.Footer)),
+		BreakingChanges: make([]Footer, 0),
+		References:      make([]Reference, 0),
+		trailers:        newTrailerEntries(m.Footer),
+		fields:          newMessageFields(m),
+	}
+
+	for _, txt := range m.Footer {
+		f := parseFooter(txt)
+		p.Footers = append(p.Footers, f)
+
+		if isBreakingChangeToken(f.Tag) {
+			p.BreakingChanges = append(p.BreakingChanges, f)
+		}
+
+		if f.Tag != "" {
+			p.References = append(p.References, parseReferences(f)...)
+		}
+	}
+
+	if len(p.BreakingChanges) == 0 && p.Header.Important {
+		p.BreakingChanges = append(p.BreakingChanges, Footer{Tag: "BREAKING CHANGE", Title: p.Header.Subject})
+	}
+
+	if p.Header.Type == "revert" {
+		content, _ := scanRevertBody(m.Body)
+
+		p.Footers = append(p.Footers, Footer{
+			Tag:     "revert",
+			Title:   p.Header.Subject,
+			Content: content,
+		})
+	}
+
+	return p
+}
+
+// isParsedFrom reports whether the representation was computed from the
+// current fields of the message
+func (p *Parsed) isParsedFrom(m *Message) bool {
+	return p.fields.equal(m)
+}
+
+// Parsed returns the structured representation of the message. It is
+// computed once and computed again when Header, Body or Footer change.
+// It is shared by the callers and must not be modified.
+func (m *Message) Parsed() *Parsed {
+	if p, ok := m.parsed.Load().(*Parsed); ok && p.isParsedFrom(m) {
+		return p
+	}
+
+	p := newParsed(m)
+	m.parsed.Store(p)
+
+	return p
+}
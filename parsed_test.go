@@ -0,0 +1,74 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageParsed(t *testing.T) {
+	msg := Parse("feat(api)!: drop v1\n\nBREAKING CHANGE: v1 is removed\nuse v2\n\nCloses #1, org/repo#2, soon\nRefs: #3")
+	parsed := msg.Parsed()
+
+	assert.Equal(t, Header{Type: "feat", Scope: "api", Subject: "drop v1", Important: true}, parsed.Header)
+	assert.Equal(t, []Footer{
+		{Tag: "BREAKING CHANGE", Title: "v1 is removed", Content: "use v2"},
+		{Tag: "Closes", Title: "#1, org/repo#2, soon"},
+		{Tag: "Refs", Title: "#3"},
+	}, parsed.Footers)
+	assert.Equal(t, []Footer{{Tag: "BREAKING CHANGE", Title: "v1 is removed", Content: "use v2"}}, parsed.BreakingChanges)
+	assert.Equal(t, []Reference{
+		{Action: "Closes", Issue: "#1"},
+		{Action: "Closes", Issue: "org/repo#2"},
+		{Action: "Refs", Issue: "#3"},
+	}, parsed.References)
+
+	assert.Same(t, parsed, msg.Parsed())
+	assert.Equal(t, []string{"#1", "org/repo#2", "soon"}, msg.GetCloses())
+	assert.True(t, msg.IsBreaking())
+}
+
+func TestMessageParsedBreakingHeader(t *testing.T) {
+	assert.Equal(t, []Footer{{Tag: "BREAKING CHANGE", Title: "drop v1"}}, Parse("feat!: drop v1").Parsed().BreakingChanges)
+	assert.Equal(t, []Footer{}, Parse("feat: add v2").Parsed().BreakingChanges)
+}
+
+func TestMessageParsedChanges(t *testing.T) {
+	msg := Parse("revert: feat: x\n\nThis reverts commit abc1234.")
+	parsed := msg.Parsed()
+
+	assert.Equal(t, []Footer{{Tag: "revert", Title: "feat: x", Content: "abc1234"}}, parsed.Footers)
+
+	// the representation follows the fields
+	msg.Body = "This reverts commit def5678."
+	assert.NotSame(t, parsed, msg.Parsed())
+	assert.Equal(t, "def5678", msg.GetFooterByField("revert").Content)
+
+	msg.Header = "fix: y"
+	assert.Equal(t, Header{Type: "fix", Subject: "y"}, msg.ParseHeader())
+
+	msg.Footer = []string{"Refs: #4"}
+	assert.Equal(t, []Reference{{Action: "Refs", Issue: "#4"}}, msg.Parsed().References)
+
+	// the wrappers return copies
+	footers := msg.ParseFooter()
+	footers[0].Title = "#5"
+	assert.Equal(t, "#4", msg.GetFooterByField("Refs").Title)
+}
+
+func TestMessageParsedLiteral(t *testing.T) {
+	msg := &Message{Header: "fix: typo", Footer: []string{"Fixes #1"}}
+
+	assert.Equal(t, "fix", msg.ParseHeader().Type)
+	assert.Equal(t, []string{"#1"}, msg.GetCloses())
+}
+
+func BenchmarkGetFooterByField(b *testing.B) {
+	msg := Parse(benchmarkMessage)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		msg.GetFooterByField("Closes")
+	}
+}
@@ -4,56 +4,55 @@ package conventionalcommitparser
 // https://www.conventionalcommits.org/en/v1.0.0/
 
 import (
-	"regexp"
 	"strings"
+	"sync/atomic"
 )
 
 type Message struct {
 	Header string
 	Body   string
 	Footer []string
+
+	// parser of the header, see ParseOptions.HeaderParser
+	parser *HeaderParser
+	parsed atomic.Value // *Parsed
 }
 
-var (
-	emptyLinePattern  = regexp.MustCompile(`^\s*$`)
-	revertBodyPattern = regexp.MustCompile(`(?i)This\sreverts\scommit\s(\w+)\.?`)
-)
+// headerParser returns the parser of the header of the message
+func (m *Message) headerParser() *HeaderParser {
+	if m.parser == nil {
+		return defaultHeaderParser
+	}
 
-func splitToLines(text string) []string {
-	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	return m.parser
 }
 
-func (m *Message) ParseHeader() Header {
-	return parseHeader(m.Header)
+func splitToLines(text string) []string {
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
 }
 
-func (m *Message) ParseFooter() []Footer {
-	footers := make([]Footer, 0)
+// afterHeader returns the body and the footers of the message, as rendered
+// by String
+func (m *Message) afterHeader() string {
+	paragraphs := make([]string, 0, 2)
 
-	for _, m := range m.Footer {
-		footers = append(footers, parseFooter(m))
+	if m.Body != "" {
+		paragraphs = append(paragraphs, m.Body)
 	}
 
-	header := m.ParseHeader()
-
-	if header.Type == "revert" {
-		matcher := revertBodyPattern.FindStringSubmatch(m.Body)
-		content := ""
-
-		if len(matcher) > 0 {
-			content = matcher[1]
-		}
+	if len(m.Footer) != 0 {
+		paragraphs = append(paragraphs, joinFooters(m.Footer))
+	}
 
-		footer := Footer{
-			Tag:     "revert",
-			Title:   header.Subject,
-			Content: content,
-		}
+	return strings.Join(paragraphs, "\n\n")
+}
 
-		footers = append(footers, footer)
-	}
+func (m *Message) ParseHeader() Header {
+	return m.Parsed().Header
+}
 
-	return footers
+func (m *Message) ParseFooter() []Footer {
+	return append(make([]Footer, 0, len(m.Parsed().Footers)), m.Parsed().Footers...)
 }
 
 func (m *Message) GetCloses() []string {
@@ -80,7 +79,7 @@ func (m *Message) GetCloses() []string {
 }
 
 func (m *Message) GetFooterByField(tags ...string) *Footer {
-	footers := m.ParseFooter()
+	footers := m.Parsed().Footers
 
 	for _, tag := range tags {
 		for _, f := range footers {
@@ -124,15 +123,228 @@ Reviewed-by: Z
 Refs: #123
 */
 func Parse(message string) *Message {
+	return ParseWith(message, ParseOptions{})
+}
+
+// ParseOptions configure ParseWith
+type ParseOptions struct {
+	// LegacyFooters starts a footer at any footer line preceded by a blank
+	// line or another footer, even in the middle of the body, and glues every
+	// following line onto the footer, as previous versions did
+	LegacyFooters bool
+	// Recover fixes the layout of the message before parsing it, see RecoverMessage
+	Recover bool
+	// HeaderParser parses the header, see NewHeaderParser. The message keeps
+	// it, so that ParseHeader, Parsed and the changelog follow its syntax.
+	// Headers follow DefaultHeaderSyntax when nil.
+	HeaderParser *HeaderParser
+}
+
+// ParseWith parses a message. The footers are the final paragraphs of the
+// message starting with a footer token, a paragraph without a token continues
+// the value of a BREAKING CHANGE footer.
+func ParseWith(message string, opts ParseOptions) *Message {
+	var msg Message
+
+	if opts.Recover {
+		message, _ = RecoverMessage(message)
+	}
+
+	msg.parser = opts.HeaderParser
+
+	if opts.LegacyFooters {
+		lines := splitToLines(message)
+		body, footer := splitLegacyFooters(lines, markdownCodeLines(lines))
+
+		msg.Header = lines[0]
+		msg.Body = strings.TrimSpace(strings.Join(body, "\n"))
+		msg.Footer = footer
+	} else {
+		msg.Header, msg.Body, msg.Footer = scanMessage(strings.ReplaceAll(message, "\r\n", "\n"))
+	}
+
+	return &msg
+}
+
+// parseWith parses a message with the header parser of another one, for
+// the messages nested in a merge or a squash
+func parseWith(message string, parser *HeaderParser) *Message {
+	return ParseWith(message, ParseOptions{HeaderParser: parser})
+}
+
+// lineScanner iterates over the lines of a text, tracking fenced code blocks
+type lineScanner struct {
+	txt string
+	// the current line is txt[start:end], the next one starts at next
+	start, end, next int
+	fence            string
+	// code reports whether the current line belongs to a fenced code block
+	code bool
+}
+
+func (s *lineScanner) scan() bool {
+	if s.next > len(s.txt) {
+		return false
+	}
+
+	s.start = s.next
+	s.end = len(s.txt)
+
+	if i := strings.IndexByte(s.txt[s.start:], '\n'); i != -1 {
+		s.end = s.start + i
+	}
+
+	s.next = s.end + 1
+	line := s.line()
+
+	if s.fence != "" {
+		s.code = true
+
+		if closesFence(line, s.fence) {
+			s.fence = ""
+		}
+	} else {
+		s.fence = fenceMarker(line)
+		s.code = s.fence != ""
+	}
+
+	return true
+}
+
+func (s *lineScanner) line() string {
+	return s.txt[s.start:s.end]
+}
+
+func (s *lineScanner) isBlank() bool {
+	return !s.code && isEmptyLine(s.line())
+}
+
+// footer returns the kind and the token of the footer started by the line
+func (s *lineScanner) footer() (footerKind, string) {
+	if s.code {
+		return footerNone, ""
+	}
+
+	kind, token, _ := scanFooter(s.line())
+
+	return kind, token
+}
+
+func isBreakingChangeToken(token string) bool {
+	token = strings.TrimSpace(token)
+
+	return strings.EqualFold(token, "BREAKING CHANGE") || strings.EqualFold(token, "BREAKING-CHANGE")
+}
+
+// scanMessage splits the message into the header, the body and the footers
+// in a single pass. The footers start at the first paragraph from which every
+// paragraph starts with a footer, or continues a BREAKING CHANGE footer.
+func scanMessage(txt string) (string, string, []string) {
+	s := lineScanner{txt: txt}
+	s.scan()
+
+	header := s.line()
+	bodyStart := s.next
+	sectionStart := -1
+	breaking := false
+	inParagraph := false
+
+	for index := 1; s.scan(); index++ {
+		if s.isBlank() {
+			inParagraph = false
+			continue
+		}
+
+		kind, token := s.footer()
+
+		if !inParagraph {
+			inParagraph = true
+
+			switch {
+			case index == 1:
+				// the line following the header is always body
+			case kind != footerNone:
+				if sectionStart == -1 {
+					sectionStart = s.start
+				}
+			case sectionStart != -1 && breaking:
+				// another paragraph of a BREAKING CHANGE
+			default:
+				sectionStart = -1
+			}
+		}
+
+		if kind != footerNone {
+			breaking = isBreakingChangeToken(token)
+		}
+	}
+
+	if bodyStart > len(txt) {
+		return header, "", make([]string, 0)
+	}
+
+	if sectionStart == -1 {
+		return header, strings.TrimSpace(txt[bodyStart:]), make([]string, 0)
+	}
+
+	return header, strings.TrimSpace(txt[bodyStart:sectionStart]), scanFooters(txt[sectionStart:])
+}
+
+// scanFooters splits the footer section, starting with a footer, into the footers.
+// The paragraphs of a footer are joined by a blank line.
+func scanFooters(txt string) []string {
+	footers := make([]string, 0, 4)
+	s := lineScanner{txt: txt}
+	start, segment, end := -1, -1, -1
+	segments := []string(nil)
+	blank := false
+
+	flush := func() {
+		if start == -1 {
+			return
+		}
+
+		if segments == nil {
+			footers = append(footers, strings.TrimSpace(txt[start:end]))
+		} else {
+			footers = append(footers, strings.TrimSpace(strings.Join(append(segments, txt[segment:end]), "\n\n")))
+			segments = nil
+		}
+	}
+
+	for s.scan() {
+		if s.isBlank() {
+			blank = true
+			continue
+		}
+
+		if kind, _ := s.footer(); kind != footerNone {
+			flush()
+			start, segment = s.start, s.start
+		} else if blank && txt[end:s.start] != "\n\n" {
+			// the paragraphs are not separated by a single empty line
+			segments = append(segments, txt[segment:end])
+			segment = s.start
+		}
+
+		end = s.end
+		blank = false
+	}
+
+	flush()
+
+	return footers
+}
+
+// splitLegacyFooters splits the lines after the header into the body and
+// the footers, see ParseOptions.LegacyFooters
+func splitLegacyFooters(lines []string, code []bool) ([]string, []string) {
 	var (
-		msg    Message
-		header string   = ""
 		body   []string = make([]string, 0)
 		footer []string = make([]string, 0)
 	)
 
-	lines := splitToLines(message)
-	index := 0
+	index := 1
 
 	for {
 		// last break
@@ -142,13 +354,6 @@ func Parse(message string) *Message {
 
 		line := lines[index]
 
-		// The first line should be header
-		if index == 0 {
-			header = line
-			index++
-			continue
-		}
-
 		// The second line should be blank
 		if index == 1 {
 			body = append(body, line)
@@ -158,8 +363,8 @@ func Parse(message string) *Message {
 
 		previousLine := lines[index-1]
 
-		// if is a footer start
-		if isFooterParagraph(line) && (emptyLinePattern.MatchString(previousLine) || isFooterParagraph(previousLine)) {
+		// if is a footer start, lines of code blocks never are
+		if !code[index] && isFooterParagraph(line) && (isEmptyLine(previousLine) || (!code[index-1] && isFooterParagraph(previousLine))) {
 			footerContent := []string{line}
 
 			index++
@@ -180,7 +385,7 @@ func Parse(message string) *Message {
 				line := lines[index]
 
 				// if match the next footer tag
-				if isFooterParagraph(line) {
+				if !code[index] && isFooterParagraph(line) {
 					footer = append(footer, strings.TrimSpace(strings.Join(footerContent, "\n")))
 					break innerLoop
 				} else {
@@ -195,9 +400,14 @@ func Parse(message string) *Message {
 		}
 	}
 
-	msg.Header = header
-	msg.Body = strings.TrimSpace(strings.Join(body, "\n"))
-	msg.Footer = footer
+	return body, footer
+}
+
+// String renders the message from its fields, see Trailers.String
+func (m *Message) String() string {
+	if txt := m.afterHeader(); txt != "" {
+		return m.Header + "\n\n" + txt
+	}
 
-	return &msg
+	return m.Header
 }
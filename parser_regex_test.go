@@ -0,0 +1,344 @@
+package conventionalcommitparser
+
+// The regular expression implementation of the parser, as of before the
+// scanner, the reference of the differential tests in scanner_test.go
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	emptyLinePattern            = regexp.MustCompile(`^\s*$`)
+	revertBodyPattern           = regexp.MustCompile(`(?i)This\sreverts\scommit\s(\w+)\.?`)
+	headerPattern               = regexp.MustCompile(`^(?i)([\s\p{L}\p{M}\p{N}_-]*)(\((.*)\))?(!?):\s+(.*)$`)
+	revertHeaderPattern         = regexp.MustCompile(`^(?i)revert\s(.*)$`)
+	footerTagPattern            = regexp.MustCompile(`(?i)^([a-z]+(-[a-z]+)*):\s?(.*)$`)
+	footerHashPattern           = regexp.MustCompile(`^(?i)^([\w\-]+)\s+(#.*)`)
+	footerBreakingChangePattern = regexp.MustCompile(`^(BREAKING\sCHANGE):\s*(.*)$`)
+)
+
+func regexParseHeader(txt string) Header {
+	if emoji, rest := splitEmoji(txt); emoji != "" {
+		header := regexParseHeader(rest)
+		header.Emoji = emoji
+
+		if g := LookupGitmoji(emoji); g != nil {
+			if header.Type == "" {
+				header.Type = g.Type
+			}
+
+			header.Important = header.Important || g.Breaking
+		}
+
+		return header
+	}
+
+	headerMatchers := headerPattern.FindStringSubmatch(txt)
+	revertHeaderMatchers := revertHeaderPattern.FindStringSubmatch(txt)
+	header := Header{}
+
+	if len(headerMatchers) != 0 { // conventional commit
+		header.Type = strings.TrimSpace(strings.ToLower(headerMatchers[1]))
+		header.Scope = strings.TrimSpace(headerMatchers[3])
+		header.Important = headerMatchers[4] == "!"
+		header.Subject = headerMatchers[5]
+	} else if len(revertHeaderMatchers) != 0 { // revert commit
+		header.Type = "revert"
+		header.Subject = unquoteRevertSubject(revertHeaderMatchers[1])
+	} else { // commom commit
+		header.Type = ""
+		header.Scope = ""
+		header.Subject = txt
+	}
+
+	return header
+}
+
+func regexPaseFooterParagraph(txt string) Footer {
+	footer := Footer{}
+
+	tagMatcher := footerTagPattern.FindStringSubmatch(txt)
+	breakingChangeMatcher := footerBreakingChangePattern.FindStringSubmatch(txt)
+	hashTagMatcher := regexHashFooter(txt)
+
+	if len(breakingChangeMatcher) != 0 {
+		footer.Tag = strings.TrimSpace(breakingChangeMatcher[1])
+		footer.Title = strings.TrimSpace(breakingChangeMatcher[2])
+	} else if len(tagMatcher) != 0 {
+		footer.Tag = strings.TrimSpace(tagMatcher[1])
+		footer.Title = strings.TrimSpace(tagMatcher[3])
+	} else if len(hashTagMatcher) != 0 {
+		footer.Tag = strings.TrimSpace(hashTagMatcher[1])
+		footer.Title = strings.TrimSpace(hashTagMatcher[2])
+	} else {
+		footer.Tag = ""
+		footer.Title = txt
+	}
+
+	return footer
+}
+
+// regexHashFooter matches the hash footers, list items are never footers
+func regexHashFooter(txt string) []string {
+	if listItemPattern.MatchString(txt) {
+		return nil
+	}
+
+	return footerHashPattern.FindStringSubmatch(txt)
+}
+
+func regexIsFooterParagraph(txt string) bool {
+	return footerBreakingChangePattern.MatchString(txt) || footerTagPattern.MatchString(txt) || regexHashFooter(txt) != nil
+}
+
+func regexParseFooter(txt string) Footer {
+	lines := splitToLines(txt)
+	footer := regexPaseFooterParagraph(lines[0])
+	footer.Content = strings.TrimSpace(strings.Join(lines[1:], "\n"))
+
+	return footer
+}
+
+func regexMarkdownCodeLines(lines []string) []bool {
+	code := make([]bool, len(lines))
+	fence := ""
+
+	for i, line := range lines {
+		if fence != "" {
+			code[i] = true
+
+			if closesFence(line, fence) {
+				fence = ""
+			}
+
+			continue
+		}
+
+		if matcher := fencePattern.FindStringSubmatch(line); matcher != nil {
+			code[i] = true
+			fence = matcher[1]
+		}
+	}
+
+	return code
+}
+
+func regexParse(message string, opts ParseOptions) *Message {
+	var (
+		msg    Message
+		body   []string
+		footer []string
+	)
+
+	lines := splitToLines(message)
+	code := regexMarkdownCodeLines(lines)
+
+	if opts.LegacyFooters {
+		body, footer = regexSplitLegacyFooters(lines, code)
+	} else {
+		body, footer = regexSplitFooters(lines, code)
+	}
+
+	msg.Header = lines[0]
+	msg.Body = strings.TrimSpace(strings.Join(body, "\n"))
+	msg.Footer = footer
+
+	return &msg
+}
+
+// regexParseFooters is Message.ParseFooter
+func regexParseFooters(m *Message) []Footer {
+	footers := make([]Footer, 0)
+
+	for _, f := range m.Footer {
+		footers = append(footers, regexParseFooter(f))
+	}
+
+	header := regexParseHeader(m.Header)
+
+	if header.Type == "revert" {
+		matcher := revertBodyPattern.FindStringSubmatch(m.Body)
+		content := ""
+
+		if len(matcher) > 0 {
+			content = matcher[1]
+		}
+
+		footers = append(footers, Footer{Tag: "revert", Title: header.Subject, Content: content})
+	}
+
+	return footers
+}
+
+func regexIsBreakingChangeFooter(line string) bool {
+	tag := regexPaseFooterParagraph(line).Tag
+
+	return strings.EqualFold(tag, "BREAKING CHANGE") || strings.EqualFold(tag, "BREAKING-CHANGE")
+}
+
+// regexSplitFooters splits the lines after the header into the body and the footers
+func regexSplitFooters(lines []string, code []bool) ([]string, []string) {
+	type paragraph struct {
+		start, end int
+	}
+
+	isBlank := func(i int) bool {
+		return !code[i] && emptyLinePattern.MatchString(lines[i])
+	}
+
+	isFooterStart := func(i int) bool {
+		return !code[i] && regexIsFooterParagraph(lines[i])
+	}
+
+	paragraphs := make([]paragraph, 0)
+
+	for i := 1; i < len(lines); {
+		if isBlank(i) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(lines) && !isBlank(i) {
+			i++
+		}
+
+		paragraphs = append(paragraphs, paragraph{start: start, end: i})
+	}
+
+	// the footers start at the first paragraph from which every paragraph
+	// starts with a footer, or continues a BREAKING CHANGE footer
+	isFooterSection := func(k int) bool {
+		// the line following the header is always body
+		if paragraphs[k].start == 1 || !isFooterStart(paragraphs[k].start) {
+			return false
+		}
+
+		breaking := false
+
+		for _, p := range paragraphs[k:] {
+			if !breaking && !isFooterStart(p.start) {
+				return false
+			}
+
+			for i := p.start; i < p.end; i++ {
+				if isFooterStart(i) {
+					breaking = regexIsBreakingChangeFooter(lines[i])
+				}
+			}
+		}
+
+		return true
+	}
+
+	first := len(paragraphs)
+
+	for k := range paragraphs {
+		if isFooterSection(k) {
+			first = k
+			break
+		}
+	}
+
+	footer := make([]string, 0)
+
+	if first == len(paragraphs) {
+		return lines[1:], footer
+	}
+
+	current := []string(nil)
+
+	flush := func() {
+		if current != nil {
+			footer = append(footer, strings.TrimSpace(strings.Join(current, "\n")))
+		}
+	}
+
+	for _, p := range paragraphs[first:] {
+		// another paragraph of the value
+		if current != nil && !isFooterStart(p.start) {
+			current = append(current, "")
+		}
+
+		for i := p.start; i < p.end; i++ {
+			if isFooterStart(i) {
+				flush()
+				current = []string{lines[i]}
+			} else {
+				current = append(current, lines[i])
+			}
+		}
+	}
+
+	flush()
+
+	return lines[1:paragraphs[first].start], footer
+}
+
+// regexSplitLegacyFooters splits the lines after the header into the body and
+// the footers, see ParseOptions.LegacyFooters
+func regexSplitLegacyFooters(lines []string, code []bool) ([]string, []string) {
+	var (
+		body   []string = make([]string, 0)
+		footer []string = make([]string, 0)
+	)
+
+	index := 1
+
+	for {
+		// last break
+		if index >= len(lines) {
+			break
+		}
+
+		line := lines[index]
+
+		// The second line should be blank
+		if index == 1 {
+			body = append(body, line)
+			index++
+			continue
+		}
+
+		previousLine := lines[index-1]
+
+		// if is a footer start, lines of code blocks never are
+		if !code[index] && regexIsFooterParagraph(line) && (emptyLinePattern.MatchString(previousLine) || (!code[index-1] && regexIsFooterParagraph(previousLine))) {
+			footerContent := []string{line}
+
+			index++
+
+			// if this line is last line
+			if index >= len(lines) {
+				footer = append(footer, strings.TrimSpace(strings.Join(footerContent, "\n")))
+				continue
+			}
+
+		innerLoop:
+			for {
+				if index >= len(lines) {
+					footer = append(footer, strings.TrimSpace(strings.Join(footerContent, "\n")))
+					break innerLoop
+				}
+
+				line := lines[index]
+
+				// if match the next footer tag
+				if !code[index] && regexIsFooterParagraph(line) {
+					footer = append(footer, strings.TrimSpace(strings.Join(footerContent, "\n")))
+					break innerLoop
+				} else {
+					footerContent = append(footerContent, line)
+					index++
+				}
+			}
+		} else {
+			body = append(body, line)
+			index++
+			continue
+		}
+	}
+
+	return body, footer
+}
@@ -540,3 +540,120 @@ app.use({})
 		})
 	}
 }
+
+func TestParseFooterSection(t *testing.T) {
+	tests := []struct {
+		name             string
+		message          string
+		wantBody         string
+		wantFooter       []string
+		wantLegacyBody   string
+		wantLegacyFooter []string
+	}{
+		{
+			name: "token lines in the body",
+			message: `fix: clear the cache
+
+The fix changes one thing.
+Note: the cache is cleared.
+
+Warning: restart needed
+after the upgrade.
+
+See the docs for details.
+
+Refs: #12`,
+			wantBody: `The fix changes one thing.
+Note: the cache is cleared.
+
+Warning: restart needed
+after the upgrade.
+
+See the docs for details.`,
+			wantFooter:     []string{"Refs: #12"},
+			wantLegacyBody: "The fix changes one thing.\nNote: the cache is cleared.",
+			wantLegacyFooter: []string{
+				"Warning: restart needed\nafter the upgrade.\n\nSee the docs for details.",
+				"Refs: #12",
+			},
+		},
+		{
+			name:             "prose after the footers",
+			message:          "feat: add search\n\nRefs: #1\n\nThanks for reviewing",
+			wantBody:         "Refs: #1\n\nThanks for reviewing",
+			wantFooter:       []string{},
+			wantLegacyBody:   "",
+			wantLegacyFooter: []string{"Refs: #1\n\nThanks for reviewing"},
+		},
+		{
+			name: "multi-paragraph breaking change",
+			message: `feat!: move the config
+
+BREAKING CHANGE: the config moved.
+
+Move config.yml to .config/.
+
+The old path is ignored.
+
+Reviewed-by: Z`,
+			wantBody: "",
+			wantFooter: []string{
+				"BREAKING CHANGE: the config moved.\n\nMove config.yml to .config/.\n\nThe old path is ignored.",
+				"Reviewed-by: Z",
+			},
+			wantLegacyBody: "",
+			wantLegacyFooter: []string{
+				"BREAKING CHANGE: the config moved.\n\nMove config.yml to .config/.\n\nThe old path is ignored.",
+				"Reviewed-by: Z",
+			},
+		},
+		{
+			name:             "multi-paragraph breaking change with a dash",
+			message:          "feat!: move the config\n\nBody.\n\nBREAKING-CHANGE: the config moved.\n\nMove it.",
+			wantBody:         "Body.",
+			wantFooter:       []string{"BREAKING-CHANGE: the config moved.\n\nMove it."},
+			wantLegacyBody:   "Body.",
+			wantLegacyFooter: []string{"BREAKING-CHANGE: the config moved.\n\nMove it."},
+		},
+		{
+			name:             "footer following the header",
+			message:          "fix: typo\nRefs: #1",
+			wantBody:         "Refs: #1",
+			wantFooter:       []string{},
+			wantLegacyBody:   "Refs: #1",
+			wantLegacyFooter: []string{},
+		},
+		{
+			name:             "list of issues",
+			message:          "chore: tidy up\n\nbody\n\n- #12 cleanup\n- #13 docs",
+			wantBody:         "body\n\n- #12 cleanup\n- #13 docs",
+			wantFooter:       []string{},
+			wantLegacyBody:   "body\n\n- #12 cleanup\n- #13 docs",
+			wantLegacyFooter: []string{},
+		},
+		{
+			name:             "list of issues before a footer",
+			message:          "chore: tidy up\n\n- #12 cleanup\n\nRefs: #1",
+			wantBody:         "- #12 cleanup",
+			wantFooter:       []string{"Refs: #1"},
+			wantLegacyBody:   "- #12 cleanup",
+			wantLegacyFooter: []string{"Refs: #1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := Parse(tt.message)
+
+			assert.Equal(t, tt.wantBody, msg.Body)
+			assert.Equal(t, tt.wantFooter, msg.Footer)
+
+			legacy := ParseWith(tt.message, ParseOptions{LegacyFooters: true})
+
+			assert.Equal(t, tt.wantLegacyBody, legacy.Body)
+			assert.Equal(t, tt.wantLegacyFooter, legacy.Footer)
+		})
+	}
+
+	msg := Parse("feat!: move the config\n\nBREAKING CHANGE: the config moved.\n\nMove config.yml to .config/.")
+	assert.Equal(t, &Footer{Tag: "BREAKING CHANGE", Title: "the config moved.", Content: "Move config.yml to .config/."}, msg.GetFooterByField("BREAKING CHANGE"))
+}
@@ -0,0 +1,140 @@
+package conventionalcommitparser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Limits bound the size of the messages read by ParseReader, a zero field is no limit
+type Limits struct {
+	// MaxBytes of the message
+	MaxBytes int
+	// MaxLines of the message
+	MaxLines int
+	// MaxLineLength in bytes, without the line ending
+	MaxLineLength int
+	// MaxFooters of the message
+	MaxFooters int
+	// MaxFooterSize in bytes of each footer
+	MaxFooterSize int
+}
+
+// DefaultLimits are generous for commit messages written by people
+var DefaultLimits = Limits{
+	MaxBytes:      1 << 20,
+	MaxLines:      10000,
+	MaxLineLength: 16 << 10,
+	MaxFooters:    100,
+	MaxFooterSize: 64 << 10,
+}
+
+// ErrLimitExceeded is matched by every *LimitError with errors.Is
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// LimitError is returned by ParseReader when a message exceeds one of its Limits
+type LimitError struct {
+	// Limit is the name of the field of Limits, `MaxLines`
+	Limit string
+	Max   int
+	// Line of the message where the limit is exceeded, from 1, 0 for MaxBytes
+	// and the footer limits
+	Line int
+}
+
+func (e *LimitError) Error() string {
+	if e.Line != 0 {
+		return fmt.Sprintf("%s of %s (%d) at line %d", ErrLimitExceeded, e.Limit, e.Max, e.Line)
+	}
+
+	return fmt.Sprintf("%s of %s (%d)", ErrLimitExceeded, e.Limit, e.Max)
+}
+
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// lineEndingLength returns the length of the `\n` or `\r\n` ending the line
+func lineEndingLength(line []byte) int {
+	if len(line) > 1 && line[len(line)-2] == '\r' {
+		return 2
+	}
+
+	return 1
+}
+
+// ParseReader reads and parses a message from an untrusted source, the
+// reading stops with a *LimitError as soon as a limit is exceeded
+func ParseReader(r io.Reader, limits Limits) (*Message, error) {
+	if limits.MaxBytes > 0 {
+		// one more byte tells a message of MaxBytes from a longer one
+		r = io.LimitReader(r, int64(limits.MaxBytes)+1)
+	}
+
+	reader := bufio.NewReader(r)
+	var b strings.Builder
+
+	for line := 1; ; line++ {
+		length := 0
+		eof := false
+
+		for {
+			chunk, err := reader.ReadSlice('\n')
+			length += len(chunk)
+			if err == nil {
+				length -= lineEndingLength(chunk)
+			}
+
+			if limits.MaxLineLength > 0 && length > limits.MaxLineLength {
+				return nil, &LimitError{Limit: "MaxLineLength", Max: limits.MaxLineLength, Line: line}
+			}
+
+			if limits.MaxBytes > 0 && b.Len()+len(chunk) > limits.MaxBytes {
+				return nil, &LimitError{Limit: "MaxBytes", Max: limits.MaxBytes}
+			}
+
+			b.Write(chunk)
+
+			if err == bufio.ErrBufferFull {
+				continue
+			}
+
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+
+		// the line ending of the last line does not start another one
+		if eof && length == 0 && line > 1 {
+			break
+		}
+
+		if limits.MaxLines > 0 && line > limits.MaxLines {
+			return nil, &LimitError{Limit: "MaxLines", Max: limits.MaxLines, Line: line}
+		}
+
+		if eof {
+			break
+		}
+	}
+
+	msg := Parse(b.String())
+
+	if limits.MaxFooters > 0 && len(msg.Footer) > limits.MaxFooters {
+		return nil, &LimitError{Limit: "MaxFooters", Max: limits.MaxFooters}
+	}
+
+	for _, footer := range msg.Footer {
+		if limits.MaxFooterSize > 0 && len(footer) > limits.MaxFooterSize {
+			return nil, &LimitError{Limit: "MaxFooterSize", Max: limits.MaxFooterSize}
+		}
+	}
+
+	return msg, nil
+}
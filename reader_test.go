@@ -0,0 +1,63 @@
+package conventionalcommitparser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReader(t *testing.T) {
+	msg, err := ParseReader(strings.NewReader(benchmarkMessage), DefaultLimits)
+	assert.Nil(t, err)
+	assert.Equal(t, Parse(benchmarkMessage), msg)
+
+	msg, err = ParseReader(iotest.OneByteReader(strings.NewReader("fix: x\r\n\r\nRefs: #1\r\n")), Limits{MaxLines: 3, MaxLineLength: 8})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Refs: #1"}, msg.Footer)
+
+	msg, err = ParseReader(strings.NewReader(""), Limits{MaxBytes: 1, MaxLines: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "", msg.Header)
+}
+
+func TestParseReaderLimits(t *testing.T) {
+	footers := "fix: x\n\nRefs: #1\nRefs: #2\nBREAKING CHANGE: " + strings.Repeat("y ", 10)
+
+	tests := []struct {
+		message string
+		limits  Limits
+		want    LimitError
+	}{
+		{"fix: x\n", Limits{MaxBytes: 6}, LimitError{Limit: "MaxBytes", Max: 6}},
+		{"fix: x\n\nbody", Limits{MaxLines: 2}, LimitError{Limit: "MaxLines", Max: 2, Line: 3}},
+		{"fix: x\n" + strings.Repeat("y", 5000), Limits{MaxLineLength: 4096}, LimitError{Limit: "MaxLineLength", Max: 4096, Line: 2}},
+		{"fix: x\r\n", Limits{MaxLineLength: 5}, LimitError{Limit: "MaxLineLength", Max: 5, Line: 1}},
+		{footers, Limits{MaxFooters: 2}, LimitError{Limit: "MaxFooters", Max: 2}},
+		{footers, Limits{MaxFooterSize: 20}, LimitError{Limit: "MaxFooterSize", Max: 20}},
+	}
+
+	for _, test := range tests {
+		msg, err := ParseReader(strings.NewReader(test.message), test.limits)
+
+		assert.Nil(t, msg, "%q", test.message)
+		assert.True(t, errors.Is(err, ErrLimitExceeded), "%q", test.message)
+
+		var limitErr *LimitError
+		if assert.True(t, errors.As(err, &limitErr), "%q", test.message) {
+			assert.Equal(t, test.want, *limitErr)
+		}
+	}
+
+	assert.Equal(t, "limit exceeded of MaxLines (2) at line 3", (&LimitError{Limit: "MaxLines", Max: 2, Line: 3}).Error())
+	assert.Equal(t, "limit exceeded of MaxBytes (6)", (&LimitError{Limit: "MaxBytes", Max: 6}).Error())
+}
+
+func TestParseReaderError(t *testing.T) {
+	errRead := errors.New("read")
+
+	_, err := ParseReader(iotest.ErrReader(errRead), DefaultLimits)
+	assert.Equal(t, errRead, err)
+}
@@ -0,0 +1,195 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Revert describes a revert commit
+type Revert struct {
+	// Hash of the reverted commit, from `This reverts commit <hash>.`
+	Hash string
+	// Depth is 1 for a revert, 2 for the revert of a revert...
+	Depth int
+	// Header of the innermost reverted commit
+	Header Header
+}
+
+// Restores reports whether the revert restores the innermost commit,
+// as the revert of a revert does
+func (r *Revert) Restores() bool {
+	return r.Depth%2 == 0
+}
+
+// `git revert` of a revert commit writes `Reapply "<header>"` since git 2.43
+var reapplyHeaderPattern = regexp.MustCompile(`^(?i)reapply\s(.*)$`)
+
+// ParseRevert returns the revert described by the message, or nil
+func (m *Message) ParseRevert() *Revert {
+	revert := Revert{}
+	txt := m.Header
+
+	for {
+		if matcher := reapplyHeaderPattern.FindStringSubmatch(txt); matcher != nil {
+			revert.Depth += 2
+			txt = unquoteRevertSubject(matcher[1])
+		} else if header := m.headerParser().Parse(txt); header.Type == "revert" {
+			revert.Depth++
+			txt = header.Subject
+		} else {
+			revert.Header = header
+			break
+		}
+	}
+
+	if revert.Depth == 0 {
+		return nil
+	}
+
+	revert.Hash, _ = scanRevertBody(m.afterHeader())
+
+	return &revert
+}
+
+// sameHash compares full and abbreviated hashes
+func sameHash(a, b string) bool {
+	if len(a) < 4 || len(b) < 4 {
+		return false
+	}
+
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	return strings.HasPrefix(strings.ToLower(b), strings.ToLower(a))
+}
+
+// hashIndex finds hashes the way sameHash compares them, without scanning
+// them all: full hashes by map, and abbreviated ones by prefix in a sorted
+// slice
+type hashIndex struct {
+	// indexes of each hash, lower case
+	hashes map[string][]int
+	// sorted are the distinct hashes, lower case
+	sorted []string
+}
+
+func newHashIndex(hashes []string) *hashIndex {
+	index := &hashIndex{hashes: make(map[string][]int, len(hashes))}
+
+	for i, hash := range hashes {
+		if len(hash) < 4 {
+			continue
+		}
+
+		hash = strings.ToLower(hash)
+
+		if _, ok := index.hashes[hash]; !ok {
+			index.sorted = append(index.sorted, hash)
+		}
+
+		index.hashes[hash] = append(index.hashes[hash], i)
+	}
+
+	sort.Strings(index.sorted)
+
+	return index
+}
+
+// lookup returns the sorted indexes of the hashes matching the hash, see sameHash
+func (x *hashIndex) lookup(hash string) []int {
+	if len(hash) < 4 {
+		return nil
+	}
+
+	hash = strings.ToLower(hash)
+	indexes := make([]int, 0, 1)
+
+	// the hashes hash abbreviates, itself included
+	for i := sort.SearchStrings(x.sorted, hash); i < len(x.sorted) && strings.HasPrefix(x.sorted[i], hash); i++ {
+		indexes = append(indexes, x.hashes[x.sorted[i]]...)
+	}
+
+	// the abbreviations of hash
+	for n := 4; n < len(hash); n++ {
+		indexes = append(indexes, x.hashes[hash[:n]]...)
+	}
+
+	sort.Ints(indexes)
+
+	return indexes
+}
+
+func commitHashes(commits []*Commit) []string {
+	hashes := make([]string, len(commits))
+
+	for i, c := range commits {
+		hashes[i] = c.Hash
+	}
+
+	return hashes
+}
+
+// CancelReverts removes the reverted commits along with their reverts.
+// A revert of a revert cancels the first revert so the original commit is
+// kept. Reverts of commits missing from the list are kept. The order of the
+// commits does not matter and is preserved.
+func CancelReverts(commits []*Commit) []*Commit {
+	index := newHashIndex(commitHashes(commits))
+	targets := make([]int, len(commits))
+	alive := make([]bool, len(commits))
+	// targeted counts the alive reverts of each commit
+	targeted := make([]int, len(commits))
+
+	for i, c := range commits {
+		targets[i] = -1
+		alive[i] = true
+
+		revert := c.Message.ParseRevert()
+		if revert == nil || revert.Hash == "" {
+			continue
+		}
+
+		for _, j := range index.lookup(revert.Hash) {
+			if j != i {
+				targets[i] = j
+				targeted[j]++
+				break
+			}
+		}
+	}
+
+	kill := func(i int) {
+		alive[i] = false
+
+		if targets[i] != -1 {
+			targeted[targets[i]]--
+		}
+	}
+
+	// the newest revert of a chain is the one no alive revert targets
+	for changed := true; changed; {
+		changed = false
+
+		for i, target := range targets {
+			if !alive[i] || target == -1 || !alive[target] || targeted[i] != 0 {
+				continue
+			}
+
+			kill(i)
+			kill(target)
+			changed = true
+		}
+	}
+
+	result := make([]*Commit, 0, len(commits))
+
+	for i, c := range commits {
+		if alive[i] {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,173 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRevert(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *Revert
+	}{
+		{
+			name:    "not a revert",
+			message: "feat: add search",
+			want:    nil,
+		},
+		{
+			name:    "revert",
+			message: "Revert \"feat(api): add search\"\n\nThis reverts commit bf08694.",
+			want:    &Revert{Hash: "bf08694", Depth: 1, Header: Header{Type: "feat", Scope: "api", Subject: "add search"}},
+		},
+		{
+			name:    "revert of a revert",
+			message: "Revert \"Revert \"feat: x\"\"\n\nThis reverts commit 1234567.",
+			want:    &Revert{Hash: "1234567", Depth: 2, Header: Header{Type: "feat", Subject: "x"}},
+		},
+		{
+			name:    "reapply",
+			message: "Reapply \"feat: x\"\n\nThis reverts commit 1234567.",
+			want:    &Revert{Hash: "1234567", Depth: 2, Header: Header{Type: "feat", Subject: "x"}},
+		},
+		{
+			name:    "revert of a reapply",
+			message: "Revert \"Reapply \"feat: x\"\"\n\nThis reverts commit 1234567.",
+			want:    &Revert{Hash: "1234567", Depth: 3, Header: Header{Type: "feat", Subject: "x"}},
+		},
+		{
+			name:    "conventional revert",
+			message: "revert: feat: x\n\nThis reverts commit 1234567.",
+			want:    &Revert{Hash: "1234567", Depth: 1, Header: Header{Type: "feat", Subject: "x"}},
+		},
+		{
+			name:    "conventional revert without hash",
+			message: "revert: change tittle\n\nrevert it",
+			want:    &Revert{Depth: 1, Header: Header{Subject: "change tittle"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.message).ParseRevert())
+		})
+	}
+
+	assert.True(t, (&Revert{Depth: 2}).Restores())
+	assert.False(t, (&Revert{Depth: 1}).Restores())
+}
+
+func TestParseRevertEdited(t *testing.T) {
+	m := Parse("Revert \"feat: x\"\n\nThis reverts commit abcdef1.")
+	assert.Equal(t, "abcdef1", m.ParseRevert().Hash)
+
+	m.Body = "This reverts commit 1234567."
+	assert.Equal(t, "1234567", m.ParseRevert().Hash)
+
+	m = Parse("Revert \"feat: x\"\n\nbody\n\nRefs: #1")
+	m.Footer[0] = "This reverts commit 1234567."
+	assert.Equal(t, "1234567", m.ParseRevert().Hash)
+}
+
+func TestParseHeaderNestedRevert(t *testing.T) {
+	assert.Equal(t, Header{Type: "revert", Subject: `Revert "feat: x"`}, parseHeader(`Revert "Revert "feat: x""`))
+	assert.Equal(t, Header{Type: "revert", Subject: "feat: x"}, parseHeader(`Revert 'feat: x'`))
+	assert.Equal(t, Header{Type: "revert", Subject: "deprecated"}, parseHeader(`Revert "deprecated`))
+}
+
+func TestCancelReverts(t *testing.T) {
+	feat := &Commit{Hash: "aaaaaaa1111", Message: Parse("feat: x")}
+	fix := &Commit{Hash: "bbbbbbb2222", Message: Parse("fix: y")}
+	revertFeat := &Commit{Hash: "ccccccc3333", Message: Parse("Revert \"feat: x\"\n\nThis reverts commit aaaaaaa.")}
+	revertRevert := &Commit{Hash: "ddddddd4444", Message: Parse("Revert \"Revert \"feat: x\"\"\n\nThis reverts commit ccccccc3333.")}
+	revertRevertRevert := &Commit{Hash: "eeeeeee5555", Message: Parse("Revert \"Reapply \"feat: x\"\"\n\nThis reverts commit ddddddd.")}
+	revertOld := &Commit{Hash: "fffffff6666", Message: Parse("Revert \"feat: old\"\n\nThis reverts commit 9999999.")}
+
+	tests := []struct {
+		name    string
+		commits []*Commit
+		want    []*Commit
+	}{
+		{
+			name:    "no reverts",
+			commits: []*Commit{feat, fix},
+			want:    []*Commit{feat, fix},
+		},
+		{
+			name:    "revert",
+			commits: []*Commit{revertFeat, fix, feat},
+			want:    []*Commit{fix},
+		},
+		{
+			name:    "revert of a revert restores",
+			commits: []*Commit{feat, revertFeat, revertRevert},
+			want:    []*Commit{feat},
+		},
+		{
+			name:    "revert of a revert in any order",
+			commits: []*Commit{revertRevert, feat, revertFeat},
+			want:    []*Commit{feat},
+		},
+		{
+			name:    "three reverts",
+			commits: []*Commit{revertRevertRevert, revertRevert, revertFeat, feat, fix},
+			want:    []*Commit{fix},
+		},
+		{
+			name:    "revert of a commit out of the range",
+			commits: []*Commit{revertOld, fix},
+			want:    []*Commit{revertOld, fix},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CancelReverts(tt.commits))
+		})
+	}
+}
+
+func TestHashIndex(t *testing.T) {
+	index := newHashIndex([]string{"abcdef1234", "ABCDEF9999", "abcd", "", "123", "abcdef1234"})
+
+	assert.Equal(t, []int{0, 2, 5}, index.lookup("abcdef1234"))
+	assert.Equal(t, []int{0, 1, 2, 5}, index.lookup("ABCDEF"))
+	assert.Equal(t, []int{2}, index.lookup("abcd0000"))
+	assert.Equal(t, []int{0, 2, 5}, index.lookup("abcdef12345678"))
+	assert.Empty(t, index.lookup("abc"))
+	assert.Empty(t, index.lookup("123"))
+	assert.Empty(t, index.lookup("0123456"))
+
+	for _, hash := range []string{"abcdef1234", "ABCDEF", "abcd0000", "abcdef12345678", "abc", "0123456"} {
+		want := make([]int, 0)
+
+		for i, other := range []string{"abcdef1234", "ABCDEF9999", "abcd", "", "123", "abcdef1234"} {
+			if sameHash(hash, other) {
+				want = append(want, i)
+			}
+		}
+
+		assert.ElementsMatch(t, want, index.lookup(hash), hash)
+	}
+}
+
+func BenchmarkCancelReverts(b *testing.B) {
+	commits := make([]*Commit, 0, 2000)
+
+	for i := 0; i < 1000; i++ {
+		hash := fmt.Sprintf("%012d%028x", i, 0)
+		commits = append(commits,
+			&Commit{Hash: hash, Message: Parse("feat: x")},
+			&Commit{Hash: fmt.Sprintf("%012d%028x", i+1000, 0), Message: Parse("Revert \"feat: x\"\n\nThis reverts commit " + hash[:12] + ".")},
+		)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if len(CancelReverts(commits)) != 0 {
+			b.Fatal("reverts left")
+		}
+	}
+}
@@ -0,0 +1,369 @@
+package conventionalcommitparser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The scanners below match the messages byte by byte, without allocating.
+// They follow the semantics of the regular expressions of previous versions,
+// kept in parser_regex_test.go, `\s` is `[\t\n\f\r ]` and `(?i)` folds `ſ`
+// to `s` and `K` (Kelvin) to `k`.
+
+// isSpaceByte reports whether c matches `\s`
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// isEmptyLine matches `^\s*$`
+func isEmptyLine(txt string) bool {
+	for i := 0; i < len(txt); i++ {
+		if !isSpaceByte(txt[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// skipSpaces returns the index of the first byte from i which is not `\s`
+func skipSpaces(txt string, i int) int {
+	for i < len(txt) && isSpaceByte(txt[i]) {
+		i++
+	}
+
+	return i
+}
+
+// isFoldedASCII reports whether r is one of the non ASCII runes
+// that `(?i)` folds to an ASCII letter
+func isFoldedASCII(r rune) bool {
+	return r == 0x017F || r == 0x212A
+}
+
+// scanASCIIRun returns the index after the run of ASCII letters starting at i,
+// along with `(?i)` folded runes. Digits and `_` are included with word.
+func scanASCIIRun(txt string, i int, word bool) int {
+	for i < len(txt) {
+		c := txt[i]
+
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			i++
+		case word && (c >= '0' && c <= '9' || c == '_'):
+			i++
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRuneInString(txt[i:])
+			if !isFoldedASCII(r) {
+				return i
+			}
+			i += size
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// foldPrefix returns the length of the prefix of txt equal to word under
+// simple case folding, -1 when txt does not start with word
+func foldPrefix(txt string, word string) int {
+	n := 0
+
+	for _, w := range word {
+		r, size := utf8.DecodeRuneInString(txt[n:])
+		if size == 0 {
+			return -1
+		}
+
+		if r != w && !foldEqual(r, w) {
+			return -1
+		}
+
+		n += size
+	}
+
+	return n
+}
+
+func foldEqual(a, b rune) bool {
+	for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+		if f == b {
+			return true
+		}
+	}
+
+	return false
+}
+
+// restOfLine reports whether txt[i:] matches `.*$`, that is has no newline
+func restOfLine(txt string, i int) bool {
+	return strings.IndexByte(txt[i:], '\n') == -1
+}
+
+// footerKind is the kind of footer a line starts
+type footerKind int
+
+const (
+	footerNone footerKind = iota
+	// footerBreakingChange is `BREAKING CHANGE: value`
+	footerBreakingChange
+	// footerToken is `Token: value`
+	footerToken
+	// footerHash is `Token #value`
+	footerHash
+)
+
+// scanBreakingChangeFooter matches `^(BREAKING\sCHANGE):\s*(.*)$`
+func scanBreakingChangeFooter(txt string) (string, string, bool) {
+	const breaking, change = "BREAKING", "CHANGE"
+
+	if len(txt) < len(breaking)+1+len(change)+1 || txt[:len(breaking)] != breaking || !isSpaceByte(txt[len(breaking)]) {
+		return "", "", false
+	}
+
+	end := len(breaking) + 1 + len(change)
+	if txt[len(breaking)+1:end] != change || txt[end] != ':' {
+		return "", "", false
+	}
+
+	value := skipSpaces(txt, end+1)
+	if !restOfLine(txt, value) {
+		return "", "", false
+	}
+
+	return txt[:end], txt[value:], true
+}
+
+// scanTokenFooter matches `(?i)^([a-z]+(-[a-z]+)*):\s?(.*)$`
+func scanTokenFooter(txt string) (string, string, bool) {
+	i := 0
+
+	for {
+		end := scanASCIIRun(txt, i, false)
+		if end == i {
+			return "", "", false
+		}
+
+		if end < len(txt) && txt[end] == '-' {
+			i = end + 1
+			continue
+		}
+
+		i = end
+		break
+	}
+
+	if i >= len(txt) || txt[i] != ':' {
+		return "", "", false
+	}
+
+	token := txt[:i]
+	value := i + 1
+
+	if value < len(txt) && isSpaceByte(txt[value]) {
+		value++
+	}
+
+	if !restOfLine(txt, value) {
+		return "", "", false
+	}
+
+	return token, txt[value:], true
+}
+
+// scanHashFooter matches `^(?i)^([\w\-]+)\s+(#.*)`, except for the list
+// items, `- #12 cleanup`
+func scanHashFooter(txt string) (string, string, bool) {
+	i := 0
+
+	for i < len(txt) {
+		end := scanASCIIRun(txt, i, true)
+
+		if end < len(txt) && txt[end] == '-' {
+			end++
+		}
+
+		if end == i {
+			break
+		}
+
+		i = end
+	}
+
+	if i == 0 || txt[:i] == "-" {
+		return "", "", false
+	}
+
+	token := txt[:i]
+	value := skipSpaces(txt, i)
+
+	if value == i || value >= len(txt) || txt[value] != '#' {
+		return "", "", false
+	}
+
+	end := strings.IndexByte(txt[value:], '\n')
+	if end == -1 {
+		return token, txt[value:], true
+	}
+
+	return token, txt[value : value+end], true
+}
+
+// scanFooter returns the token and the raw value of the footer started by
+// the line, in the order of precedence of paseFooterParagraph
+func scanFooter(txt string) (footerKind, string, string) {
+	if token, value, ok := scanBreakingChangeFooter(txt); ok {
+		return footerBreakingChange, token, value
+	}
+
+	if token, value, ok := scanTokenFooter(txt); ok {
+		return footerToken, token, value
+	}
+
+	if token, value, ok := scanHashFooter(txt); ok {
+		return footerHash, token, value
+	}
+
+	return footerNone, "", ""
+}
+
+// isTypeRune reports whether r is in `[\s\p{L}\p{M}\p{N}_-]`
+func isTypeRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		c := byte(r)
+		return isSpaceByte(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+	}
+
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsNumber(r)
+}
+
+// scanSubject matches `(!?):\s+(.*)$` at i
+func scanSubject(txt string, i int) (bool, string, bool) {
+	important := false
+
+	if i < len(txt) && txt[i] == '!' {
+		important = true
+		i++
+	}
+
+	if i >= len(txt) || txt[i] != ':' {
+		return false, "", false
+	}
+
+	subject := skipSpaces(txt, i+1)
+	if subject == i+1 || !restOfLine(txt, subject) {
+		return false, "", false
+	}
+
+	return important, txt[subject:], true
+}
+
+// scanConventionalHeader matches
+// `^(?i)([\s\p{L}\p{M}\p{N}_-]*)(\((.*)\))?(!?):\s+(.*)$` and returns the
+// type, the scope, the `!` and the subject as written
+func scanConventionalHeader(txt string) (string, string, bool, string, bool) {
+	i := 0
+
+	for i < len(txt) {
+		r, size := utf8.DecodeRuneInString(txt[i:])
+		if !isTypeRune(r) {
+			break
+		}
+		i += size
+	}
+
+	kind := txt[:i]
+
+	// the scope runs to the last `)` followed by the subject, on the same line
+	if i < len(txt) && txt[i] == '(' {
+		line := len(txt)
+		if nl := strings.IndexByte(txt[i:], '\n'); nl != -1 {
+			line = i + nl
+		}
+
+		for end := strings.LastIndexByte(txt[i+1:line], ')'); end != -1; end = strings.LastIndexByte(txt[i+1:i+1+end], ')') {
+			if important, subject, ok := scanSubject(txt, i+1+end+1); ok {
+				return kind, txt[i+1 : i+1+end], important, subject, true
+			}
+		}
+	}
+
+	important, subject, ok := scanSubject(txt, i)
+
+	return kind, "", important, subject, ok
+}
+
+// scanRevertHeader matches `^(?i)revert\s(.*)$`
+func scanRevertHeader(txt string) (string, bool) {
+	n := foldPrefix(txt, "revert")
+	if n == -1 || n >= len(txt) || !isSpaceByte(txt[n]) || !restOfLine(txt, n+1) {
+		return "", false
+	}
+
+	return txt[n+1:], true
+}
+
+// scanRevertBody matches `(?i)This\sreverts\scommit\s(\w+)\.?` anywhere in the text
+func scanRevertBody(txt string) (string, bool) {
+	for start := 0; start < len(txt); start++ {
+		if c := txt[start]; c != 't' && c != 'T' {
+			continue
+		}
+
+		i := start
+
+		for j, word := range [...]string{"this", "reverts", "commit"} {
+			if j != 0 {
+				if i >= len(txt) || !isSpaceByte(txt[i]) {
+					i = -1
+					break
+				}
+				i++
+			}
+
+			n := foldPrefix(txt[i:], word)
+			if n == -1 {
+				i = -1
+				break
+			}
+			i += n
+		}
+
+		if i == -1 || i >= len(txt) || !isSpaceByte(txt[i]) {
+			continue
+		}
+
+		if end := scanASCIIRun(txt, i+1, true); end != i+1 {
+			return txt[i+1 : end], true
+		}
+	}
+
+	return "", false
+}
+
+// fenceMarker returns the backticks or tildes opening a fenced code block,
+// as `^ {0,3}(`{3,}|~{3,})`, or an empty string
+func fenceMarker(line string) string {
+	i := 0
+	for i < 3 && i < len(line) && line[i] == ' ' {
+		i++
+	}
+
+	if i >= len(line) || (line[i] != '`' && line[i] != '~') {
+		return ""
+	}
+
+	end := i
+	for end < len(line) && line[end] == line[i] {
+		end++
+	}
+
+	if end-i < 3 {
+		return ""
+	}
+
+	return line[i:end]
+}
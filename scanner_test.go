@@ -0,0 +1,194 @@
+package conventionalcommitparser
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var scannerHeaders = []string{
+	"feat: add search",
+	"feat(api)!: drop v1",
+	"fix(a)(b): c",
+	"feat(a): b (c): d",
+	"feat(a) : b",
+	"feat(: x",
+	"feat(a:b): c",
+	"feat!: x",
+	"feat:x",
+	"feat: ",
+	"feat:\tx",
+	"  feat : x",
+	"(scope): x",
+	": x",
+	"feat\x80: x",
+	"ſeat: x",
+	"功能(界面): 添加按钮",
+	"✨ feat(ui): add dark mode",
+	":bug: fix: crash",
+	"🐛 Fix login",
+	"Revert \"feat: x\"",
+	"revert: x",
+	"REVERT  x",
+	"Revert",
+	"Merge branch 'main' into dev",
+	"",
+}
+
+var scannerLines = []string{
+	"",
+	"  ",
+	"\t",
+	"\v",
+	"\r",
+	"prose line",
+	"x\ry",
+	"Refs: #1",
+	"Refs:#1",
+	"Refs:",
+	"Refs:  spaced  ",
+	"Closes #1, #2",
+	"Closes  #1",
+	"Fixes #12  ",
+	"-a #1",
+	"a_b-c #1",
+	"a-: x",
+	"a--b: x",
+	"BREAKING CHANGE: x",
+	"BREAKING\tCHANGE: y",
+	"BREAKING CHANGE:",
+	"BREAKING CHANGE:   ",
+	"BREAKING CHANGES: x",
+	"BREAKING-CHANGE: z",
+	"breaking-change: z",
+	"Reviewed-by: Z",
+	"Co-authored-by: A <a@b.c>",
+	"ſigned-off-by: K",
+	"Signed-off-by: A\r",
+	"Note: text",
+	"key: value",
+	"```",
+	"```yaml",
+	"~~~",
+	"~~",
+	"   ```",
+	"    ```",
+	"This reverts commit abc123.",
+	"this  reverts commit abc",
+	"thiſ reverts commit ſK.",
+	"> Note: quote",
+	"- item",
+}
+
+// randomMessage builds a message of random headers and lines
+func randomMessage(r *rand.Rand) string {
+	lines := []string{scannerHeaders[r.Intn(len(scannerHeaders))]}
+
+	for n := r.Intn(12); n > 0; n-- {
+		lines = append(lines, scannerLines[r.Intn(len(scannerLines))])
+	}
+
+	separator := "\n"
+	if r.Intn(8) == 0 {
+		separator = "\r\n"
+	}
+
+	return strings.Join(lines, separator)
+}
+
+func TestScannerDifferential(t *testing.T) {
+	for _, txt := range append(append([]string{}, scannerHeaders...), scannerLines...) {
+		for _, line := range []string{txt, txt + "\nnext", "feat:\n" + txt} {
+			assert.Equal(t, regexParseHeader(line), parseHeader(line), "%q", line)
+			assert.Equal(t, regexPaseFooterParagraph(line), paseFooterParagraph(line), "%q", line)
+			assert.Equal(t, regexIsFooterParagraph(line), isFooterParagraph(line), "%q", line)
+			assert.Equal(t, regexParseFooter(line), parseFooter(line), "%q", line)
+			assert.Equal(t, regexMarkdownCodeLines([]string{line}), markdownCodeLines([]string{line}), "%q", line)
+			assert.Equal(t, emptyLinePattern.MatchString(line), isEmptyLine(line), "%q", line)
+
+			hash, _ := scanRevertBody(line)
+			want := ""
+			if matcher := revertBodyPattern.FindStringSubmatch(line); matcher != nil {
+				want = matcher[1]
+			}
+			assert.Equal(t, want, hash, "%q", line)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 5000; i++ {
+		message := randomMessage(r)
+
+		for _, opts := range []ParseOptions{{}, {LegacyFooters: true}} {
+			want := regexParse(message, opts)
+			got := ParseWith(message, opts)
+
+			if !assert.Equal(t, want, got, "%q", message) {
+				return
+			}
+
+			assert.Equal(t, regexParseFooters(want), got.ParseFooter(), "%q", message)
+		}
+	}
+}
+
+const benchmarkMessage = `fix(api): prevent racing of requests
+
+Introduce a request id and a reference to latest request. Dismiss
+incoming responses other than from latest request.
+
+Remove timeouts which were used to mitigate the racing issue but are
+obsolete now.
+
+BREAKING CHANGE: use '.use()' instead of '.load()'
+
+Reviewed-by: Z
+Closes #123, #124
+Signed-off-by: A <a@example.com>`
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Parse(benchmarkMessage)
+	}
+}
+
+func BenchmarkParseRegex(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		regexParse(benchmarkMessage, ParseOptions{})
+	}
+}
+
+func BenchmarkParseFooter(b *testing.B) {
+	msg := Parse(benchmarkMessage)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		msg.ParseFooter()
+	}
+}
+
+func BenchmarkParseFooterRegex(b *testing.B) {
+	msg := Parse(benchmarkMessage)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		regexParseFooters(msg)
+	}
+}
+
+func BenchmarkParseHeader(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		parseHeader("fix(api)!: prevent racing of requests")
+	}
+}
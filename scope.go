@@ -0,0 +1,166 @@
+package conventionalcommitparser
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultScopeSeparators split `feat(api,web)`, `fix(payments/stripe)`,
+// `fix(api|web)` and `fix(api web)` into their elements
+const DefaultScopeSeparators = ",/| "
+
+// ScopeSyntax describes how a raw scope is split into hierarchical scopes
+type ScopeSyntax struct {
+	// Separators between scopes, `api,web`
+	Separators string
+	// HierarchySeparators between the levels of a scope, `payments/stripe`
+	HierarchySeparators string
+}
+
+var DefaultScopeSyntax = ScopeSyntax{
+	Separators:          ", |",
+	HierarchySeparators: "/",
+}
+
+// Scope is a hierarchical scope
+type Scope struct {
+	// Levels of the scope, from the root to the leaf
+	Levels []string
+	// separators between the levels, as written, `/` when missing
+	separators []string
+}
+
+// String returns the scope as written in the header, `payments/stripe`
+// or `payments.stripe`
+func (s Scope) String() string {
+	var b strings.Builder
+
+	for i, level := range s.Levels {
+		if i != 0 {
+			if i-1 < len(s.separators) {
+				b.WriteString(s.separators[i-1])
+			} else {
+				b.WriteString("/")
+			}
+		}
+
+		b.WriteString(level)
+	}
+
+	return b.String()
+}
+
+// Title returns the scope for display, `payments > stripe`
+func (s Scope) Title() string {
+	return strings.Join(s.Levels, " > ")
+}
+
+// Parent returns the scope one level up, an empty scope for a root scope
+func (s Scope) Parent() Scope {
+	if len(s.Levels) <= 1 {
+		return Scope{}
+	}
+
+	parent := Scope{Levels: s.Levels[:len(s.Levels)-1]}
+
+	if n := len(s.Levels) - 2; n <= len(s.separators) {
+		parent.separators = s.separators[:n]
+	}
+
+	return parent
+}
+
+// Contains reports whether other is s or one of its descendants
+func (s Scope) Contains(other Scope) bool {
+	if len(other.Levels) < len(s.Levels) {
+		return false
+	}
+
+	for i := range s.Levels {
+		if !strings.EqualFold(s.Levels[i], other.Levels[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func splitScope(raw string, separators string) []string {
+	elements := strings.FieldsFunc(raw, func(r rune) bool {
+		return strings.ContainsRune(separators, r)
+	})
+
+	result := make([]string, 0, len(elements))
+
+	for _, e := range elements {
+		if e = strings.TrimSpace(e); e != "" {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// splitScopeLevels splits a scope into its levels, keeping the separators
+// between them, `payments//stripe` has the separator `//`
+func splitScopeLevels(raw string, separators string) Scope {
+	scope := Scope{}
+	separator := ""
+	start := 0
+
+	flush := func(end int) {
+		if level := strings.TrimSpace(raw[start:end]); level != "" {
+			if len(scope.Levels) != 0 {
+				scope.separators = append(scope.separators, separator)
+			}
+
+			scope.Levels = append(scope.Levels, level)
+			separator = ""
+		}
+	}
+
+	for i, r := range raw {
+		if !strings.ContainsRune(separators, r) {
+			continue
+		}
+
+		flush(i)
+		start = i + utf8.RuneLen(r)
+
+		if len(scope.Levels) != 0 {
+			separator += string(r)
+		}
+	}
+
+	flush(len(raw))
+
+	return scope
+}
+
+// ParseScopes splits a raw scope into hierarchical scopes
+func ParseScopes(raw string, syntax ScopeSyntax) []Scope {
+	scopes := make([]Scope, 0)
+
+	for _, s := range splitScope(raw, syntax.Separators) {
+		if scope := splitScopeLevels(s, syntax.HierarchySeparators); len(scope.Levels) != 0 {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+// Scopes returns every element of the scope, split on DefaultScopeSeparators
+func (h Header) Scopes() []string {
+	return splitScope(h.Scope, DefaultScopeSeparators)
+}
+
+// ScopesWith returns every element of the scope, split on the separators
+func (h Header) ScopesWith(separators string) []string {
+	return splitScope(h.Scope, separators)
+}
+
+// HierarchicalScopes returns the scopes of the header split with DefaultScopeSyntax
+func (h Header) HierarchicalScopes() []Scope {
+	return ParseScopes(h.Scope, DefaultScopeSyntax)
+}
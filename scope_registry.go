@@ -0,0 +1,257 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScopeDefinition declares a scope of the registry
+type ScopeDefinition struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Owner       string   `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Paths       []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+}
+
+// ScopeRegistry is the vocabulary of scopes allowed in headers.
+// The order of the scopes is the order of the changelog sections.
+//
+//	scopes:
+//	  - name: api
+//	    description: Public HTTP API
+//	    owner: "@org/api"
+//	    paths: ["services/api/**"]
+//	  - name: payments/stripe
+//	    paths: ["payments/stripe/**"]
+type ScopeRegistry struct {
+	Scopes []ScopeDefinition `yaml:"scopes" json:"scopes"`
+}
+
+// ParseScopeRegistry parses a YAML or JSON registry
+func ParseScopeRegistry(data []byte) (*ScopeRegistry, error) {
+	registry := ScopeRegistry{}
+
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("parse scope registry: %w", err)
+	}
+
+	for i, s := range registry.Scopes {
+		if strings.TrimSpace(s.Name) == "" {
+			return nil, fmt.Errorf("parse scope registry: scope #%d has no name", i+1)
+		}
+	}
+
+	return &registry, nil
+}
+
+// LoadScopeRegistry reads a YAML or JSON registry file
+func LoadScopeRegistry(filepath string) (*ScopeRegistry, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseScopeRegistry(data)
+}
+
+// Lookup returns the definition of the scope, case-insensitively
+func (r *ScopeRegistry) Lookup(name string) *ScopeDefinition {
+	for i := range r.Scopes {
+		if strings.EqualFold(r.Scopes[i].Name, name) {
+			return &r.Scopes[i]
+		}
+	}
+
+	return nil
+}
+
+// Index returns the position of the scope in the registry, or -1
+func (r *ScopeRegistry) Index(name string) int {
+	for i := range r.Scopes {
+		if strings.EqualFold(r.Scopes[i].Name, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Less orders scopes as declared in the registry, unknown scopes last
+func (r *ScopeRegistry) Less(a, b string) bool {
+	i, j := r.Index(a), r.Index(b)
+
+	switch {
+	case i == -1 && j == -1:
+		return a < b
+	case i == -1:
+		return false
+	case j == -1:
+		return true
+	}
+
+	return i < j
+}
+
+// Suggest returns the registered scope closest to a misspelled one, or an empty string
+func (r *ScopeRegistry) Suggest(name string) string {
+	best := ""
+	bestDistance := 0
+	name = strings.ToLower(name)
+
+	for _, s := range r.Scopes {
+		distance := levenshtein(name, strings.ToLower(s.Name))
+		threshold := len(s.Name) / 3
+
+		if threshold < 2 {
+			threshold = 2
+		}
+
+		if distance <= threshold && (best == "" || distance < bestDistance) {
+			best = s.Name
+			bestDistance = distance
+		}
+	}
+
+	return best
+}
+
+// ScopesForPaths returns the scopes a commit changing the paths should use,
+// in registry order. Each path belongs to the scope with the most specific
+// matching glob.
+func (r *ScopeRegistry) ScopesForPaths(paths []string) []string {
+	matched := make(map[int]bool)
+
+	for _, p := range paths {
+		best := -1
+		bestPattern := ""
+
+		for i, s := range r.Scopes {
+			for _, pattern := range s.Paths {
+				if matchPathGlob(pattern, p) && len(pattern) > len(bestPattern) {
+					best = i
+					bestPattern = pattern
+				}
+			}
+		}
+
+		if best != -1 {
+			matched[best] = true
+		}
+	}
+
+	scopes := make([]string, 0, len(matched))
+
+	for i, s := range r.Scopes {
+		if matched[i] {
+			scopes = append(scopes, s.Name)
+		}
+	}
+
+	return scopes
+}
+
+// ScopeEnumRule reports the scopes of a header missing from the registry
+func ScopeEnumRule(r *ScopeRegistry) LintRule {
+	return LintRule{
+		Name: "scope-enum",
+		Check: func(c *Commit) []string {
+			messages := make([]string, 0)
+
+			for _, scope := range c.Message.ParseHeader().HierarchicalScopes() {
+				name := scope.String()
+
+				if r.Lookup(name) != nil {
+					continue
+				}
+
+				if suggestion := r.Suggest(name); suggestion != "" {
+					messages = append(messages, fmt.Sprintf("scope %q is not registered, did you mean %q?", name, suggestion))
+				} else {
+					messages = append(messages, fmt.Sprintf("scope %q is not registered", name))
+				}
+			}
+
+			return messages
+		},
+	}
+}
+
+// matchPathGlob matches a slash separated path against a glob where `**`
+// matches any number of directories. A pattern without a slash matches the
+// base name, `*.md`.
+func matchPathGlob(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	name = strings.TrimPrefix(name, "/")
+
+	if !strings.Contains(pattern, "/") && pattern != "**" {
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(patterns, names []string) bool {
+	for len(patterns) != 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(names) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(patterns[0], names[0]); !ok {
+			return false
+		}
+
+		patterns, names = patterns[1:], names[1:]
+	}
+
+	return len(names) == 0
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,172 @@
+package conventionalcommitparser
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const scopeRegistryYAML = `
+scopes:
+  - name: api
+    description: Public HTTP API
+    owner: "@org/api"
+    paths: ["services/api/**"]
+  - name: payments
+    owner: "@org/payments"
+    paths: ["payments/**"]
+  - name: payments/stripe
+    owner: "@org/payments"
+    paths: ["payments/stripe/**"]
+  - name: docs
+    paths: ["docs/**", "*.md"]
+`
+
+const scopeRegistryJSON = `{
+  "scopes": [
+    {"name": "api", "description": "Public HTTP API", "owner": "@org/api", "paths": ["services/api/**"]},
+    {"name": "payments", "owner": "@org/payments", "paths": ["payments/**"]},
+    {"name": "payments/stripe", "owner": "@org/payments", "paths": ["payments/stripe/**"]},
+    {"name": "docs", "paths": ["docs/**", "*.md"]}
+  ]
+}`
+
+func testScopeRegistry(t *testing.T) *ScopeRegistry {
+	registry, err := ParseScopeRegistry([]byte(scopeRegistryYAML))
+	assert.NoError(t, err)
+
+	return registry
+}
+
+func TestParseScopeRegistry(t *testing.T) {
+	fromYAML, err := ParseScopeRegistry([]byte(scopeRegistryYAML))
+	assert.NoError(t, err)
+
+	fromJSON, err := ParseScopeRegistry([]byte(scopeRegistryJSON))
+	assert.NoError(t, err)
+
+	assert.Equal(t, fromYAML, fromJSON)
+	assert.Equal(t, ScopeDefinition{
+		Name:        "api",
+		Description: "Public HTTP API",
+		Owner:       "@org/api",
+		Paths:       []string{"services/api/**"},
+	}, fromYAML.Scopes[0])
+
+	_, err = ParseScopeRegistry([]byte("scopes:\n  - owner: x\n"))
+	assert.Error(t, err)
+
+	_, err = ParseScopeRegistry([]byte("scopes: [\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadScopeRegistry(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "scopes.json")
+	assert.NoError(t, os.WriteFile(file, []byte(scopeRegistryJSON), 0o644))
+
+	registry, err := LoadScopeRegistry(file)
+	assert.NoError(t, err)
+	assert.Len(t, registry.Scopes, 4)
+
+	_, err = LoadScopeRegistry(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestScopeRegistrySuggest(t *testing.T) {
+	registry := testScopeRegistry(t)
+
+	assert.Equal(t, "payments", registry.Suggest("paymnts"))
+	assert.Equal(t, "api", registry.Suggest("API"))
+	assert.Equal(t, "docs", registry.Suggest("doc"))
+	assert.Equal(t, "", registry.Suggest("frontend"))
+}
+
+func TestScopeRegistryScopesForPaths(t *testing.T) {
+	registry := testScopeRegistry(t)
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{name: "no paths", paths: nil, want: []string{}},
+		{name: "unknown path", paths: []string{"main.go"}, want: []string{}},
+		{name: "single scope", paths: []string{"services/api/server.go"}, want: []string{"api"}},
+		{name: "most specific scope", paths: []string{"payments/stripe/client.go"}, want: []string{"payments/stripe"}},
+		{name: "base name glob", paths: []string{"README.md"}, want: []string{"docs"}},
+		{name: "longest glob wins", paths: []string{"services/api/README.md"}, want: []string{"api"}},
+		{
+			name:  "registry order",
+			paths: []string{"docs/index.md", "payments/refund.go", "services/api/server.go"},
+			want:  []string{"api", "payments", "docs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, registry.ScopesForPaths(tt.paths))
+		})
+	}
+}
+
+func TestScopeRegistryLess(t *testing.T) {
+	registry := testScopeRegistry(t)
+	scopes := []string{"zeta", "docs", "alpha", "api", "Payments"}
+
+	sort.Slice(scopes, func(i, j int) bool { return registry.Less(scopes[i], scopes[j]) })
+
+	assert.Equal(t, []string{"api", "Payments", "docs", "alpha", "zeta"}, scopes)
+}
+
+func TestScopeEnumRule(t *testing.T) {
+	rule := ScopeEnumRule(testScopeRegistry(t))
+
+	tests := []struct {
+		message string
+		want    []LintProblem
+	}{
+		{message: "feat: no scope", want: []LintProblem{}},
+		{message: "feat(api,payments/stripe): subject", want: []LintProblem{}},
+		{
+			message: "feat(paymnts): subject",
+			want:    []LintProblem{{Rule: "scope-enum", Message: `scope "paymnts" is not registered, did you mean "payments"?`}},
+		},
+		{
+			message: "feat(api,frontend): subject",
+			want:    []LintProblem{{Rule: "scope-enum", Message: `scope "frontend" is not registered`}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, Lint(&Commit{Message: Parse(tt.message)}, rule))
+		})
+	}
+
+	dotted := ScopeEnumRule(&ScopeRegistry{Scopes: []ScopeDefinition{{Name: "payments.stripe"}}})
+	assert.Equal(t, []LintProblem{}, Lint(&Commit{Message: Parse("fix(payments.stripe): subject")}, dotted))
+}
+
+func Test_matchPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "docs/**", name: "docs/a/b.md", want: true},
+		{pattern: "docs/**", name: "docs", want: true},
+		{pattern: "docs/**", name: "src/docs/a.md", want: false},
+		{pattern: "**/*_test.go", name: "a/b/c_test.go", want: true},
+		{pattern: "**/*_test.go", name: "c_test.go", want: true},
+		{pattern: "*.md", name: "a/b/README.md", want: true},
+		{pattern: "cmd/*/main.go", name: "cmd/cli/main.go", want: true},
+		{pattern: "cmd/*/main.go", name: "cmd/cli/sub/main.go", want: false},
+		{pattern: "/go.mod", name: "go.mod", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchPathGlob(tt.pattern, tt.name))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{name: "no scope", header: "feat: subject", want: []string{}},
+		{name: "single scope", header: "feat(api): subject", want: []string{"api"}},
+		{name: "comma", header: "feat(api, web): subject", want: []string{"api", "web"}},
+		{name: "slash", header: "fix(payments/stripe): subject", want: []string{"payments", "stripe"}},
+		{name: "pipe", header: "fix(api|web): subject", want: []string{"api", "web"}},
+		{name: "space", header: "fix(api web): subject", want: []string{"api", "web"}},
+		{name: "dash is not a separator", header: "fix(scope-1): subject", want: []string{"scope-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseHeader(tt.header).Scopes())
+		})
+	}
+}
+
+func TestHeaderScopesWith(t *testing.T) {
+	header := parseHeader("fix(payments/stripe,web): subject")
+
+	assert.Equal(t, []string{"payments/stripe", "web"}, header.ScopesWith(","))
+}
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		syntax ScopeSyntax
+		want   []Scope
+	}{
+		{
+			name:   "empty",
+			raw:    "",
+			syntax: DefaultScopeSyntax,
+			want:   []Scope{},
+		},
+		{
+			name:   "multiple",
+			raw:    "api,web",
+			syntax: DefaultScopeSyntax,
+			want:   []Scope{{Levels: []string{"api"}}, {Levels: []string{"web"}}},
+		},
+		{
+			name:   "hierarchical",
+			raw:    "payments/stripe, web",
+			syntax: DefaultScopeSyntax,
+			want:   []Scope{{Levels: []string{"payments", "stripe"}, separators: []string{"/"}}, {Levels: []string{"web"}}},
+		},
+		{
+			name:   "custom syntax",
+			raw:    "payments.stripe;web",
+			syntax: ScopeSyntax{Separators: ";", HierarchySeparators: "."},
+			want:   []Scope{{Levels: []string{"payments", "stripe"}, separators: []string{"."}}, {Levels: []string{"web"}}},
+		},
+		{
+			name:   "empty levels",
+			raw:    "payments//stripe,,",
+			syntax: DefaultScopeSyntax,
+			want:   []Scope{{Levels: []string{"payments", "stripe"}, separators: []string{"//"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseScopes(tt.raw, tt.syntax))
+		})
+	}
+}
+
+func TestScope(t *testing.T) {
+	scope := Scope{Levels: []string{"payments", "stripe"}}
+
+	assert.Equal(t, "payments/stripe", scope.String())
+	assert.Equal(t, "payments > stripe", scope.Title())
+	assert.Equal(t, Scope{Levels: []string{"payments"}}, scope.Parent())
+	assert.Empty(t, scope.Parent().Parent().Levels)
+	assert.True(t, Scope{Levels: []string{"Payments"}}.Contains(scope))
+	assert.True(t, scope.Contains(scope))
+	assert.False(t, scope.Contains(Scope{Levels: []string{"payments"}}))
+	assert.False(t, Scope{Levels: []string{"web"}}.Contains(scope))
+	assert.Equal(t, []string{"payments", "stripe"}, parseHeader("fix(payments/stripe): subject").HierarchicalScopes()[0].Levels)
+}
+
+func TestScopeString(t *testing.T) {
+	syntax := ScopeSyntax{Separators: ",", HierarchySeparators: "./:"}
+
+	for _, raw := range []string{"payments.stripe", "payments/stripe", "a.b:c", "web"} {
+		scopes := ParseScopes(raw, syntax)
+		assert.Len(t, scopes, 1)
+		assert.Equal(t, raw, scopes[0].String())
+	}
+
+	scope := ParseScopes("a.b:c", syntax)[0]
+	assert.Equal(t, "a.b", scope.Parent().String())
+	assert.Equal(t, "a > b > c", scope.Title())
+}
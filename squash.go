@@ -0,0 +1,159 @@
+package conventionalcommitparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Squash describes a squash merge, `feat(api): add search (#812)` whose
+// body lists the squashed commits as `* fix: typo` bullets, or separated
+// by `---` lines, followed by the `Co-authored-by` trailers
+type Squash struct {
+	// Number of the pull request, from the end of the header, 0 when missing
+	Number int
+	// Messages of the squashed commits, the trailers of the squash
+	// commit are left out
+	Messages []*Message
+}
+
+var (
+	squashNumberPattern    = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+	squashBulletPattern    = regexp.MustCompile(`^([*-]\s+)(.*)$`)
+	squashSeparatorPattern = regexp.MustCompile(`^-{3,}\s*$`)
+)
+
+// isSquashedHeader reports whether the line is a conventional header
+func isSquashedHeader(line string, parser *HeaderParser) bool {
+	_, _, _, _, ok := parser.match(line)
+
+	return ok && parser.Parse(line).Type != ""
+}
+
+// separatesHeaders reports whether a `---` line of the body is followed by
+// a conventional header, GitHub also separates the co-authors with one
+func separatesHeaders(lines []string, parser *HeaderParser) bool {
+	for i, line := range lines {
+		if !squashSeparatorPattern.MatchString(line) {
+			continue
+		}
+
+		for _, next := range lines[i+1:] {
+			if strings.TrimSpace(next) != "" {
+				if isSquashedHeader(next, parser) {
+					return true
+				}
+
+				break
+			}
+		}
+	}
+
+	return false
+}
+
+// squashedEntries splits the body of a squash commit into the messages of the squashed commits
+func squashedEntries(txt string, parser *HeaderParser) []string {
+	entries := make([]string, 0)
+	current := []string(nil)
+	indent := ""
+	lines := splitToLines(txt)
+	// only bullets start entries, unless `---` lines separate headers: a
+	// paragraph of the body may well start with `word: text`
+	separated := separatesHeaders(lines, parser)
+
+	flush := func() {
+		if current != nil {
+			entries = append(entries, strings.TrimSpace(strings.Join(current, "\n")))
+		}
+		current = nil
+		indent = ""
+	}
+
+	for _, line := range lines {
+		if squashSeparatorPattern.MatchString(line) {
+			flush()
+			separated = true
+			continue
+		}
+
+		if matcher := squashBulletPattern.FindStringSubmatch(line); matcher != nil && isSquashedHeader(matcher[2], parser) {
+			flush()
+			current = []string{matcher[2]}
+			indent = strings.Repeat(" ", len(matcher[1]))
+			separated = false
+			continue
+		}
+
+		// in a list separated by `---`, the first header of each part starts
+		// an entry without a bullet
+		if separated && current == nil && isSquashedHeader(line, parser) {
+			current = []string{line}
+			separated = false
+			continue
+		}
+
+		if current != nil {
+			current = append(current, strings.TrimPrefix(line, indent))
+		}
+	}
+
+	flush()
+
+	return entries
+}
+
+// ParseSquash returns the squash merge described by the message,
+// nil when there is neither a pull request number nor squashed commits
+func (m *Message) ParseSquash() *Squash {
+	squash := Squash{Messages: make([]*Message, 0)}
+
+	if matcher := squashNumberPattern.FindStringSubmatch(m.Header); matcher != nil {
+		squash.Number, _ = strconv.Atoi(matcher[1])
+	}
+
+	// the trailer block, Co-authored-by..., belongs to the squash commit
+	buf := completeLine(m.Header + "\n\n" + m.afterHeader())
+	info := TrailerOptions{NoDivider: true}.parseTrailerInfo(buf)
+	headerLen := len(m.Header) + 2
+
+	if info.start > headerLen {
+		for _, entry := range squashedEntries(buf[headerLen:info.start], m.headerParser()) {
+			squash.Messages = append(squash.Messages, parseWith(entry, m.parser))
+		}
+	}
+
+	if squash.Number == 0 && len(squash.Messages) == 0 {
+		return nil
+	}
+
+	return &squash
+}
+
+// ExpandSquashes follows each squash commit with a commit for each of its
+// squashed commits, sharing its hash and paths. Squashed commits with the
+// same header as the squash commit are left out.
+func ExpandSquashes(commits []*Commit) []*Commit {
+	result := make([]*Commit, 0, len(commits))
+
+	for _, c := range commits {
+		result = append(result, c)
+
+		squash := c.Message.ParseSquash()
+		if squash == nil {
+			continue
+		}
+
+		header := squashNumberPattern.ReplaceAllString(c.Message.Header, "")
+
+		for _, msg := range squash.Messages {
+			if msg.Header == header {
+				continue
+			}
+
+			result = append(result, &Commit{Hash: c.Hash, Message: msg, Paths: c.Paths})
+		}
+	}
+
+	return result
+}
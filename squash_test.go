@@ -0,0 +1,128 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSquash(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		number  int
+		headers []string
+		bodies  []string
+		isNil   bool
+	}{
+		{
+			name:    "not a squash",
+			message: "feat: add search\n\nsome body",
+			isNil:   true,
+		},
+		{
+			name:    "pull request number only",
+			message: "feat(api): add search (#812)",
+			number:  812,
+			headers: []string{},
+			bodies:  []string{},
+		},
+		{
+			name: "github bullets with co-authors",
+			message: `feat(api): add search (#812)
+
+* fix: typo
+
+* feat: paging
+  details of paging
+
+---------
+
+Co-authored-by: A <a@example.com>`,
+			number:  812,
+			headers: []string{"fix: typo", "feat: paging"},
+			bodies:  []string{"", "details of paging"},
+		},
+		{
+			name: "non conventional bullets are body text",
+			message: `feat(api): add search (#812)
+
+* feat: paging
+- handles empty pages
+* fix(api)!: reject negative pages`,
+			number:  812,
+			headers: []string{"feat: paging", "fix(api)!: reject negative pages"},
+			bodies:  []string{"- handles empty pages", ""},
+		},
+		{
+			name: "separated entries",
+			message: `feat(api): add search
+
+fix: typo
+---
+feat: paging
+
+more details
+
+Signed-off-by: A <a@example.com>`,
+			headers: []string{"fix: typo", "feat: paging"},
+			bodies:  []string{"", "more details"},
+		},
+		{
+			name:    "body paragraph like a header",
+			message: "fix(api): handle empty queries\n\nfeat: flag was ignored by the handler,\nso every query failed\n\nSigned-off-by: A",
+			isNil:   true,
+		},
+		{
+			name:    "body paragraph before co-authors",
+			message: "fix(api): handle empty queries (#9)\n\nfeat: flag was ignored by the handler\n\n---------\n\nCo-authored-by: A <a@example.com>",
+			number:  9,
+			headers: []string{},
+			bodies:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			squash := Parse(tt.message).ParseSquash()
+
+			if tt.isNil {
+				assert.Nil(t, squash)
+				return
+			}
+
+			headers := make([]string, 0)
+			bodies := make([]string, 0)
+
+			for _, m := range squash.Messages {
+				headers = append(headers, m.Header)
+				bodies = append(bodies, m.Body)
+			}
+
+			assert.Equal(t, tt.number, squash.Number)
+			assert.Equal(t, tt.headers, headers)
+			assert.Equal(t, tt.bodies, bodies)
+		})
+	}
+}
+
+func TestExpandSquashes(t *testing.T) {
+	commits := []*Commit{
+		{Hash: "1111111", Message: Parse("docs: readme")},
+		{Hash: "2222222", Paths: []string{"api.go"}, Message: Parse("feat(api): add search (#812)\n\n* feat(api): add search\n\n* fix: typo\n\nCo-authored-by: A <a@example.com>")},
+	}
+
+	expanded := ExpandSquashes(commits)
+
+	assert.Len(t, expanded, 3)
+	assert.Equal(t, commits[0], expanded[0])
+	assert.Equal(t, commits[1], expanded[1])
+	assert.Equal(t, "fix: typo", expanded[2].Message.Header)
+	assert.Equal(t, "2222222", expanded[2].Hash)
+	assert.Equal(t, []string{"api.go"}, expanded[2].Paths)
+	assert.Equal(t, []string{"A <a@example.com>"}, expanded[1].Message.Trailers().Values("Co-authored-by"))
+	assert.Equal(t, 0, expanded[2].Message.Trailers().Len())
+
+	squashed := []*Commit{{Message: Parse("docs: readme (#1)\n\n* fix: typo")}}
+	assert.Equal(t, BumpNone, DefaultTypeRegistry.BumpCommits(squashed))
+	assert.Equal(t, BumpPatch, DefaultTypeRegistry.BumpCommits(ExpandSquashes(squashed)))
+}
@@ -0,0 +1,180 @@
+package conventionalcommitparser
+
+import (
+	"path"
+	"strings"
+)
+
+// SuggestOptions configure SuggestHeader
+type SuggestOptions struct {
+	// Scopes map the paths to scopes with their globs, see
+	// ScopeRegistry.ScopesForPaths. The top-level directories are the
+	// scopes when it is nil or none of its globs match.
+	Scopes *ScopeRegistry
+	// Type of the changes to source code, `feat` when empty
+	Type string
+}
+
+// the most scopes of a suggestion, broader changes get no scope
+const maxSuggestedScopes = 3
+
+// directories grouping the scopes, `pkg/parser` is the parser scope
+var scopeContainerDirectories = map[string]bool{
+	"cmd": true, "pkg": true, "internal": true, "src": true, "lib": true,
+	"packages": true, "apps": true, "services": true, "modules": true,
+}
+
+// directories that are no scopes, the types tell them
+var nonScopeDirectories = map[string]bool{
+	"docs": true, "doc": true, "test": true, "tests": true, "testdata": true, "__tests__": true,
+}
+
+// isDependencyPath reports whether the path only lists dependencies, `go.sum`
+func isDependencyPath(p string) bool {
+	switch path.Base(p) {
+	case "go.mod", "go.sum", "go.work.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock", "Gemfile.lock", "requirements.txt":
+		return true
+	}
+
+	return false
+}
+
+// directoryScope returns the top-level directory of the path, or the
+// directory under a container such as `pkg`, empty for root files
+func directoryScope(p string) string {
+	parts := strings.Split(strings.TrimPrefix(path.Clean(strings.ReplaceAll(p, "\\", "/")), "./"), "/")
+	dirs := parts[:len(parts)-1]
+
+	if len(dirs) > 1 && scopeContainerDirectories[dirs[0]] {
+		dirs = dirs[1:]
+	}
+
+	if len(dirs) == 0 || strings.HasPrefix(dirs[0], ".") || nonScopeDirectories[dirs[0]] {
+		return ""
+	}
+
+	return dirs[0]
+}
+
+// directoryScopes returns the scopes of the paths, in order of appearance
+func directoryScopes(paths []string) []string {
+	scopes := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, p := range paths {
+		if scope := directoryScope(p); scope != "" && !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+// SuggestHeader returns the header of a commit changing the paths, without
+// subject. Changes to tests only are `test`, to documentation only `docs`,
+// to CI configuration only `ci`, to dependencies only `build(deps)` and to
+// other build files only `build`.
+//
+//	SuggestHeader([]string{"pkg/parser/scan.go"}, SuggestOptions{}).String() // feat(parser):
+func SuggestHeader(paths []string, opts SuggestOptions) Header {
+	header := Header{Type: opts.Type}
+
+	if header.Type == "" {
+		header.Type = "feat"
+	}
+
+	if t := pathsType(paths); t != "" {
+		header.Type = t
+	}
+
+	scopes := []string(nil)
+
+	if opts.Scopes != nil {
+		scopes = opts.Scopes.ScopesForPaths(paths)
+	}
+
+	if len(scopes) == 0 {
+		scopes = directoryScopes(paths)
+	}
+
+	if len(scopes) == 0 && header.Type == "build" {
+		deps := len(paths) != 0
+
+		for _, p := range paths {
+			deps = deps && isDependencyPath(p)
+		}
+
+		if deps {
+			scopes = []string{"deps"}
+		}
+	}
+
+	if len(scopes) <= maxSuggestedScopes {
+		header.Scope = strings.Join(scopes, ",")
+	}
+
+	return header
+}
+
+// String formats the header, parsing it gives back the header when it has a type
+func (h Header) String() string {
+	var b strings.Builder
+
+	if h.Emoji != "" {
+		b.WriteString(h.Emoji + " ")
+	}
+
+	if h.Type == "" {
+		b.WriteString(h.Subject)
+		return b.String()
+	}
+
+	b.WriteString(h.Type)
+
+	if h.Scope != "" {
+		b.WriteString("(" + h.Scope + ")")
+	}
+
+	if h.Important {
+		b.WriteString("!")
+	}
+
+	b.WriteString(": " + h.Subject)
+
+	return b.String()
+}
+
+// PrepareCommitMessage prefills the header of a message being written, for
+// the prepare-commit-msg hook of git. The source is the second argument of
+// the hook, the header is only suggested to new messages, without source or
+// from a template, which have no header yet. The paths are the staged files,
+// `git diff --cached --name-only`.
+//
+//	data, _ := os.ReadFile(os.Args[1])
+//	message := PrepareCommitMessage(string(data), source, paths, SuggestOptions{})
+//	os.WriteFile(os.Args[1], []byte(message), 0644)
+func PrepareCommitMessage(message string, source string, paths []string, opts SuggestOptions) string {
+	if (source != "" && source != "template") || len(paths) == 0 {
+		return message
+	}
+
+	lines := splitToLines(message)
+
+	// the header is the first line that is no git comment
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !isEmptyLine(line) {
+			return message
+		}
+
+		lines[i] = SuggestHeader(paths, opts).String()
+
+		return strings.Join(lines, "\n")
+	}
+
+	return SuggestHeader(paths, opts).String() + "\n" + message
+}
@@ -0,0 +1,62 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestHeader(t *testing.T) {
+	registry, err := ParseScopeRegistry([]byte(scopeRegistryYAML))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		paths []string
+		opts  SuggestOptions
+		want  string
+	}{
+		{[]string{"parser.go"}, SuggestOptions{}, "feat: "},
+		{[]string{"parser.go"}, SuggestOptions{Type: "fix"}, "fix: "},
+		{[]string{"api/server.go", "api/routes.go"}, SuggestOptions{}, "feat(api): "},
+		{[]string{"pkg/parser/scan.go", "cmd/cli/main.go"}, SuggestOptions{}, "feat(parser,cli): "},
+		{[]string{"a/x.go", "b/x.go", "c/x.go", "d/x.go"}, SuggestOptions{}, "feat: "},
+		{[]string{"parser_test.go", "testdata/a.txt"}, SuggestOptions{}, "test: "},
+		{[]string{"api/server_test.go"}, SuggestOptions{}, "test(api): "},
+		{[]string{"docs/install.md", "README.md"}, SuggestOptions{}, "docs: "},
+		{[]string{"go.mod", "go.sum"}, SuggestOptions{}, "build(deps): "},
+		{[]string{"Makefile"}, SuggestOptions{}, "build: "},
+		{[]string{".github/workflows/ci.yml"}, SuggestOptions{}, "ci: "},
+		{[]string{"services/api/handler.go"}, SuggestOptions{Scopes: registry}, "feat(api): "},
+		{[]string{"payments/stripe/charge.go", "services/api/a_test.go"}, SuggestOptions{Scopes: registry}, "feat(api,payments/stripe): "},
+		{[]string{"tools/lint.go"}, SuggestOptions{Scopes: registry}, "feat(tools): "},
+		{nil, SuggestOptions{}, "feat: "},
+	}
+
+	for _, test := range tests {
+		header := SuggestHeader(test.paths, test.opts)
+
+		assert.Equal(t, test.want, header.String(), "%v", test.paths)
+		assert.Equal(t, header, parseHeader(header.String()), "%v", test.paths)
+	}
+}
+
+func TestHeaderString(t *testing.T) {
+	for _, txt := range []string{"feat(api)!: drop v1", "✨ feat: x", ":bug: fix(a,b): y", "Fix login", "feat: "} {
+		assert.Equal(t, txt, parseHeader(txt).String())
+	}
+}
+
+func TestPrepareCommitMessage(t *testing.T) {
+	paths := []string{"api/server.go"}
+	template := "\n# Please enter the commit message for your changes.\n"
+
+	assert.Equal(t, "feat(api): \n# Please enter the commit message for your changes.\n", PrepareCommitMessage(template, "", paths, SuggestOptions{}))
+	assert.Equal(t, "# Title\nfeat(api): \n\nBody", PrepareCommitMessage("# Title\n\n\nBody", "template", paths, SuggestOptions{}))
+	assert.Equal(t, "feat(api): \n# comment", PrepareCommitMessage("# comment", "", paths, SuggestOptions{}))
+
+	// messages with a header, amended, merged or from -m are kept
+	assert.Equal(t, "fix: x\n# comment", PrepareCommitMessage("fix: x\n# comment", "", paths, SuggestOptions{}))
+	assert.Equal(t, template, PrepareCommitMessage(template, "message", paths, SuggestOptions{}))
+	assert.Equal(t, template, PrepareCommitMessage(template, "commit", paths, SuggestOptions{}))
+	assert.Equal(t, template, PrepareCommitMessage(template, "", nil, SuggestOptions{}))
+}
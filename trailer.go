@@ -0,0 +1,726 @@
+package conventionalcommitparser
+
+// Git trailer engine
+// Mirrors the semantics of `git interpret-trailers`
+// https://git-scm.com/docs/git-interpret-trailers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type TrailerWhere string
+
+const (
+	TrailerWhereAfter  TrailerWhere = "after"
+	TrailerWhereBefore TrailerWhere = "before"
+	TrailerWhereEnd    TrailerWhere = "end"
+	TrailerWhereStart  TrailerWhere = "start"
+)
+
+type TrailerIfExists string
+
+const (
+	TrailerIfExistsAddIfDifferentNeighbor TrailerIfExists = "addIfDifferentNeighbor"
+	TrailerIfExistsAddIfDifferent         TrailerIfExists = "addIfDifferent"
+	TrailerIfExistsAdd                    TrailerIfExists = "add"
+	TrailerIfExistsReplace                TrailerIfExists = "replace"
+	TrailerIfExistsDoNothing              TrailerIfExists = "doNothing"
+)
+
+type TrailerIfMissing string
+
+const (
+	TrailerIfMissingAdd       TrailerIfMissing = "add"
+	TrailerIfMissingDoNothing TrailerIfMissing = "doNothing"
+)
+
+// Trailer is a single item of a trailer block.
+// Lines of the block which are not trailers have an empty Token and
+// are kept verbatim in Value.
+type Trailer struct {
+	Token string
+	Value string
+}
+
+// TrailerKeyConfig is the equivalent of the `trailer.<Name>.*` git config.
+// Empty fields inherit the values of TrailerOptions.
+type TrailerKeyConfig struct {
+	Name      string
+	Key       string
+	Where     TrailerWhere
+	IfExists  TrailerIfExists
+	IfMissing TrailerIfMissing
+}
+
+// TrailerOptions are the equivalent of the `trailer.*` git config and
+// the `git interpret-trailers` flags. The zero value matches git defaults.
+type TrailerOptions struct {
+	Separators string // trailer.separators, default ":"
+	Where      TrailerWhere
+	IfExists   TrailerIfExists
+	IfMissing  TrailerIfMissing
+	Keys       []TrailerKeyConfig
+
+	NoDivider    bool // --no-divider
+	Unfold       bool // --unfold
+	TrimEmpty    bool // --trim-empty
+	OnlyTrailers bool // --only-trailers
+	OnlyInput    bool // --only-input
+}
+
+// TrailerArg is the equivalent of a `--trailer` flag.
+// Non-empty Where, IfExists and IfMissing override the configured values.
+type TrailerArg struct {
+	Trailer   string
+	Where     TrailerWhere
+	IfExists  TrailerIfExists
+	IfMissing TrailerIfMissing
+}
+
+var ErrEmptyTrailerToken = errors.New("empty trailer token")
+
+const (
+	trailerCommentChar = '#'
+	trailerCutLine     = "------------------------ >8 ------------------------"
+)
+
+var trailerGitGeneratedPrefixes = []string{"Signed-off-by: ", "(cherry picked from commit "}
+
+type trailerConf struct {
+	where     TrailerWhere
+	ifExists  TrailerIfExists
+	ifMissing TrailerIfMissing
+}
+
+type trailerItem struct {
+	token string
+	value string
+	conf  trailerConf
+}
+
+func (o TrailerOptions) separators() string {
+	if o.Separators == "" {
+		return ":"
+	}
+
+	return o.Separators
+}
+
+func (o TrailerOptions) defaultConf() trailerConf {
+	conf := trailerConf{
+		where:     TrailerWhereEnd,
+		ifExists:  TrailerIfExistsAddIfDifferentNeighbor,
+		ifMissing: TrailerIfMissingAdd,
+	}
+
+	return conf.merge(o.Where, o.IfExists, o.IfMissing)
+}
+
+func (c trailerConf) merge(where TrailerWhere, ifExists TrailerIfExists, ifMissing TrailerIfMissing) trailerConf {
+	if where != "" {
+		c.where = where
+	}
+
+	if ifExists != "" {
+		c.ifExists = ifExists
+	}
+
+	if ifMissing != "" {
+		c.ifMissing = ifMissing
+	}
+
+	return c
+}
+
+func isGitSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+func isGitAlnum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func trimGitSpace(s string) string {
+	return strings.TrimFunc(s, func(r rune) bool { return r < 0x80 && isGitSpace(byte(r)) })
+}
+
+// nextLine returns the offset of the line following the one at i
+func nextLine(buf string, i int) int {
+	if n := strings.IndexByte(buf[i:], '\n'); n >= 0 {
+		return i + n + 1
+	}
+
+	return len(buf)
+}
+
+// lastLine returns the offset of the last line of buf[:n], or -1
+func lastLine(buf string, n int) int {
+	if n == 0 {
+		return -1
+	}
+
+	if n == 1 {
+		return 0
+	}
+
+	// the last character is skipped, a trailing newline belongs to the last line anyway
+	return strings.LastIndexByte(buf[:n-1], '\n') + 1
+}
+
+func isBlankLine(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			return true
+		}
+
+		if !isGitSpace(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findSeparator returns the position of the separator of a trailer line, or -1
+func findSeparator(line string, separators string) int {
+	whitespaceFound := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		if strings.IndexByte(separators, c) >= 0 {
+			return i
+		}
+
+		if !whitespaceFound && (isGitAlnum(c) || c == '-') {
+			continue
+		}
+
+		if i != 0 && (c == ' ' || c == '\t') {
+			whitespaceFound = true
+			continue
+		}
+
+		break
+	}
+
+	return -1
+}
+
+func findPatchStart(buf string) int {
+	for i := 0; i < len(buf); i = nextLine(buf, i) {
+		if strings.HasPrefix(buf[i:], "---") && (len(buf) == i+3 || isGitSpace(buf[i+3])) {
+			return i
+		}
+	}
+
+	return len(buf)
+}
+
+// locateScissors returns the offset of the `# ---- >8 ----` line, or n
+func locateScissors(buf string, n int) int {
+	pattern := "\n" + string(trailerCommentChar) + " " + trailerCutLine
+
+	if strings.HasPrefix(buf[:n], pattern[1:]) {
+		return 0
+	}
+
+	if i := strings.Index(buf[:n], pattern); i >= 0 {
+		return i + 1
+	}
+
+	return n
+}
+
+// ignoreNonTrailer returns the length of the trailing comments, blank lines
+// and old-style conflicts block of buf[:n]
+func ignoreNonTrailer(buf string, n int) int {
+	boc := 0
+	bol := 0
+	inConflictsBlock := false
+	cutoff := locateScissors(buf, n)
+
+	for bol < cutoff {
+		next := nextLine(buf[:n], bol)
+
+		switch {
+		case buf[bol] == trailerCommentChar || buf[bol] == '\n':
+			if boc == 0 {
+				boc = bol
+			}
+		case strings.HasPrefix(buf[bol:n], "Conflicts:\n"):
+			inConflictsBlock = true
+			if boc == 0 {
+				boc = bol
+			}
+		case inConflictsBlock && buf[bol] == '\t':
+			// a pathname in the conflicts block
+		case boc != 0:
+			boc = 0
+			inConflictsBlock = false
+		}
+
+		bol = next
+	}
+
+	if boc != 0 {
+		return n - boc
+	}
+
+	return n - cutoff
+}
+
+func (o TrailerOptions) findEndOfLogMessage(buf string) int {
+	n := len(buf)
+
+	if !o.NoDivider {
+		n = findPatchStart(buf)
+	}
+
+	return n - ignoreNonTrailer(buf, n)
+}
+
+func (o TrailerOptions) matchKey(token string, tokenLen int) *TrailerKeyConfig {
+	prefix := token[:tokenLen]
+
+	for i := range o.Keys {
+		key := &o.Keys[i]
+
+		if hasPrefixFold(key.Name, prefix) || (key.Key != "" && hasPrefixFold(key.Key, prefix)) {
+			return key
+		}
+	}
+
+	return nil
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// findTrailerStart applies the heuristic of git: the last paragraph is a
+// trailer block when all its lines are trailers, or when it contains a git
+// generated or configured trailer and at least 25% of its lines are trailers
+func (o TrailerOptions) findTrailerStart(buf string, n int) int {
+	separators := o.separators()
+	onlySpaces := true
+	recognizedPrefix := false
+	trailerLines, nonTrailerLines, possibleContinuationLines := 0, 0, 0
+
+	// The first paragraph is the title and cannot be trailers
+	endOfTitle := 0
+	for endOfTitle < n {
+		if buf[endOfTitle] != trailerCommentChar && isBlankLine(buf[endOfTitle:]) {
+			break
+		}
+		endOfTitle = nextLine(buf, endOfTitle)
+	}
+
+outer:
+	for l := lastLine(buf, n); l >= endOfTitle; l = lastLine(buf, l) {
+		line := buf[l:]
+
+		if line[0] == trailerCommentChar {
+			nonTrailerLines += possibleContinuationLines
+			possibleContinuationLines = 0
+			continue
+		}
+
+		if isBlankLine(line) {
+			if onlySpaces {
+				continue
+			}
+
+			nonTrailerLines += possibleContinuationLines
+
+			if (recognizedPrefix && trailerLines*3 >= nonTrailerLines) || (trailerLines != 0 && nonTrailerLines == 0) {
+				return nextLine(buf, l)
+			}
+
+			return n
+		}
+
+		onlySpaces = false
+
+		for _, prefix := range trailerGitGeneratedPrefixes {
+			if strings.HasPrefix(line, prefix) {
+				trailerLines++
+				possibleContinuationLines = 0
+				recognizedPrefix = true
+				continue outer
+			}
+		}
+
+		separatorPos := findSeparator(line, separators)
+
+		switch {
+		case separatorPos >= 1 && !isGitSpace(line[0]):
+			trailerLines++
+			possibleContinuationLines = 0
+
+			if !recognizedPrefix && o.matchKey(line, separatorPos) != nil {
+				recognizedPrefix = true
+			}
+		case isGitSpace(line[0]):
+			possibleContinuationLines++
+		default:
+			nonTrailerLines++
+			nonTrailerLines += possibleContinuationLines
+			possibleContinuationLines = 0
+		}
+	}
+
+	return n
+}
+
+func tokenLenWithoutSeparator(token string) int {
+	n := len(token)
+
+	for n > 0 && !isGitAlnum(token[n-1]) {
+		n--
+	}
+
+	return n
+}
+
+// parseTrailer splits a trailer into token and value and resolves the token
+// against the configured keys
+func (o TrailerOptions) parseTrailer(trailer string, separatorPos int) (string, string, trailerConf) {
+	var token, value string
+
+	if separatorPos != -1 {
+		token = trimGitSpace(trailer[:separatorPos])
+		value = trimGitSpace(trailer[separatorPos+1:])
+	} else {
+		token = trimGitSpace(trailer)
+	}
+
+	conf := o.defaultConf()
+
+	if key := o.matchKey(token, tokenLenWithoutSeparator(token)); key != nil {
+		conf = conf.merge(key.Where, key.IfExists, key.IfMissing)
+
+		if key.Key != "" {
+			token = key.Key
+		}
+	}
+
+	return token, value, conf
+}
+
+func unfoldValue(value string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(value); {
+		c := value[i]
+		i++
+
+		if c == '\n' {
+			// collapse continuation down to a single space
+			for i < len(value) && isGitSpace(value[i]) {
+				i++
+			}
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(c)
+		}
+	}
+
+	return trimGitSpace(b.String())
+}
+
+type trailerInfo struct {
+	start           int
+	end             int
+	blankLineBefore bool
+	items           []trailerItem
+}
+
+func (o TrailerOptions) parseTrailerInfo(buf string) trailerInfo {
+	separators := o.separators()
+	info := trailerInfo{}
+
+	info.end = o.findEndOfLogMessage(buf)
+	info.start = o.findTrailerStart(buf, info.end)
+
+	if l := lastLine(buf, info.start); l >= 0 {
+		info.blankLineBefore = isBlankLine(buf[l:])
+	}
+
+	// join continuation lines to their trailer
+	lines := make([]string, 0)
+	continuable := false
+
+	for i := info.start; i < info.end; {
+		next := nextLine(buf[:info.end], i)
+		line := buf[i:next]
+		i = next
+
+		if continuable && isGitSpace(line[0]) {
+			lines[len(lines)-1] += line
+			continue
+		}
+
+		lines = append(lines, line)
+		continuable = findSeparator(line, separators) >= 1
+	}
+
+	for _, line := range lines {
+		if line[0] == trailerCommentChar {
+			continue
+		}
+
+		if separatorPos := findSeparator(line, separators); separatorPos >= 1 {
+			token, value, _ := o.parseTrailer(line, separatorPos)
+
+			if o.Unfold {
+				value = unfoldValue(value)
+			}
+
+			info.items = append(info.items, trailerItem{token: token, value: value})
+		} else if !o.OnlyTrailers {
+			info.items = append(info.items, trailerItem{value: strings.TrimSuffix(line, "\n")})
+		}
+	}
+
+	return info
+}
+
+func completeLine(buf string) string {
+	if buf != "" && !strings.HasSuffix(buf, "\n") {
+		return buf + "\n"
+	}
+
+	return buf
+}
+
+// ParseTrailers returns the items of the trailer block of a message
+func ParseTrailers(message string, opts TrailerOptions) []Trailer {
+	info := opts.parseTrailerInfo(completeLine(message))
+	trailers := make([]Trailer, 0, len(info.items))
+
+	for _, item := range info.items {
+		trailers = append(trailers, Trailer{Token: item.token, Value: item.value})
+	}
+
+	return trailers
+}
+
+func sameToken(a, b string) bool {
+	if a == "" {
+		return false
+	}
+
+	aLen := tokenLenWithoutSeparator(a)
+	bLen := tokenLenWithoutSeparator(b)
+	minLen := aLen
+
+	if bLen < minLen {
+		minLen = bLen
+	}
+
+	// git compares only the common prefix of both tokens
+	return strings.EqualFold(a[:minLen], b[:minLen])
+}
+
+func sameTrailer(a, b trailerItem) bool {
+	return sameToken(a.token, b.token) && strings.EqualFold(a.value, b.value)
+}
+
+func afterOrEnd(where TrailerWhere) bool {
+	return where == TrailerWhereAfter || where == TrailerWhereEnd
+}
+
+func insertTrailerItem(items []trailerItem, index int, item trailerItem) []trailerItem {
+	items = append(items, trailerItem{})
+	copy(items[index+1:], items[index:])
+	items[index] = item
+
+	return items
+}
+
+func removeTrailerItem(items []trailerItem, index int) []trailerItem {
+	return append(items[:index], items[index+1:]...)
+}
+
+// addNextTo inserts arg after (after, end) or before (before, start) items[on]
+func addNextTo(items []trailerItem, on int, arg trailerItem) []trailerItem {
+	if afterOrEnd(arg.conf.where) {
+		return insertTrailerItem(items, on+1, arg)
+	}
+
+	return insertTrailerItem(items, on, arg)
+}
+
+func checkIfDifferent(items []trailerItem, in int, arg trailerItem, checkAll bool) bool {
+	for {
+		if sameTrailer(items[in], arg) {
+			return false
+		}
+
+		if afterOrEnd(arg.conf.where) {
+			in--
+		} else {
+			in++
+		}
+
+		if !checkAll || in < 0 || in >= len(items) {
+			return true
+		}
+	}
+}
+
+func applyArgIfExists(items []trailerItem, in int, on int, arg trailerItem) []trailerItem {
+	switch arg.conf.ifExists {
+	case TrailerIfExistsDoNothing:
+	case TrailerIfExistsReplace:
+		items = addNextTo(items, on, arg)
+		// inserting before (before, start) shifts the existing trailer
+		if afterOrEnd(arg.conf.where) {
+			items = removeTrailerItem(items, in)
+		} else {
+			items = removeTrailerItem(items, in+1)
+		}
+	case TrailerIfExistsAdd:
+		items = addNextTo(items, on, arg)
+	case TrailerIfExistsAddIfDifferent:
+		if checkIfDifferent(items, in, arg, true) {
+			items = addNextTo(items, on, arg)
+		}
+	default: // addIfDifferentNeighbor
+		if checkIfDifferent(items, on, arg, false) {
+			items = addNextTo(items, on, arg)
+		}
+	}
+
+	return items
+}
+
+func applyArg(items []trailerItem, arg trailerItem) []trailerItem {
+	where := arg.conf.where
+	middle := where == TrailerWhereAfter || where == TrailerWhereBefore
+	backwards := afterOrEnd(where)
+
+	if len(items) != 0 {
+		start := 0
+		if backwards {
+			start = len(items) - 1
+		}
+
+		for i := range items {
+			in := i
+			if backwards {
+				in = len(items) - 1 - i
+			}
+
+			if !sameToken(items[in].token, arg.token) {
+				continue
+			}
+
+			on := start
+			if middle {
+				on = in
+			}
+
+			return applyArgIfExists(items, in, on, arg)
+		}
+	}
+
+	if arg.conf.ifMissing == TrailerIfMissingDoNothing {
+		return items
+	}
+
+	if afterOrEnd(where) {
+		return append(items, arg)
+	}
+
+	return insertTrailerItem(items, 0, arg)
+}
+
+func (o TrailerOptions) formatTrailer(b *strings.Builder, item trailerItem) {
+	if item.token == "" {
+		b.WriteString(item.value)
+		b.WriteByte('\n')
+		return
+	}
+
+	token := strings.TrimRightFunc(item.token, func(r rune) bool { return r < 0x80 && isGitSpace(byte(r)) })
+
+	if token == "" {
+		return
+	}
+
+	separators := o.separators()
+
+	b.WriteString(item.token)
+
+	if strings.IndexByte(separators, token[len(token)-1]) < 0 {
+		b.WriteByte(separators[0])
+		b.WriteByte(' ')
+	}
+
+	b.WriteString(item.value)
+	b.WriteByte('\n')
+}
+
+// InterpretTrailers adds the trailers to the message the way
+// `git interpret-trailers --trailer <arg>...` does.
+// Unlike git, which only reports them, an error is returned for trailers with an empty token.
+// Trailer commands (`trailer.<token>.command`) are not supported.
+func InterpretTrailers(message string, opts TrailerOptions, args ...TrailerArg) (string, error) {
+	// as git, a message without a final newline is not completed, the line
+	// break before the trailers ends its last line
+	buf := message
+	info := opts.parseTrailerInfo(buf)
+	items := info.items
+
+	if !opts.OnlyInput {
+		separators := "=" + opts.separators()
+
+		for _, arg := range args {
+			separatorPos := findSeparator(arg.Trailer, separators)
+
+			if separatorPos == 0 {
+				return "", fmt.Errorf("%w in trailer '%s'", ErrEmptyTrailerToken, trimGitSpace(arg.Trailer))
+			}
+
+			token, value, conf := opts.parseTrailer(arg.Trailer, separatorPos)
+
+			items = applyArg(items, trailerItem{
+				token: token,
+				value: value,
+				conf:  conf.merge(arg.Where, arg.IfExists, arg.IfMissing),
+			})
+		}
+	}
+
+	var b strings.Builder
+
+	if !opts.OnlyTrailers {
+		b.WriteString(buf[:info.start])
+
+		if !info.blankLineBefore {
+			b.WriteByte('\n')
+		}
+	}
+
+	for _, item := range items {
+		if opts.TrimEmpty && item.value == "" {
+			continue
+		}
+
+		if opts.OnlyTrailers && item.token == "" {
+			continue
+		}
+
+		opts.formatTrailer(&b, item)
+	}
+
+	if !opts.OnlyTrailers {
+		b.WriteString(buf[info.end:])
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,328 @@
+package conventionalcommitparser
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrailers(t *testing.T) {
+	type args struct {
+		message string
+		opts    TrailerOptions
+	}
+	tests := []struct {
+		name string
+		args args
+		want []Trailer
+	}{
+		{
+			name: "no trailers",
+			args: args{message: "feat: subject\n\nbody"},
+			want: []Trailer{},
+		},
+		{
+			name: "title is never a trailer",
+			args: args{message: "Refs: #1"},
+			want: []Trailer{},
+		},
+		{
+			name: "token with digits",
+			args: args{message: "feat: subject\n\nX-Ref2: abc\nReviewed-by: Z"},
+			want: []Trailer{{Token: "X-Ref2", Value: "abc"}, {Token: "Reviewed-by", Value: "Z"}},
+		},
+		{
+			name: "continuation line",
+			args: args{message: "feat: subject\n\nNote: first\n  second\nRefs: #1"},
+			want: []Trailer{{Token: "Note", Value: "first\n  second"}, {Token: "Refs", Value: "#1"}},
+		},
+		{
+			name: "unfold continuation line",
+			args: args{message: "feat: subject\n\nNote: first\n  second\nRefs: #1", opts: TrailerOptions{Unfold: true}},
+			want: []Trailer{{Token: "Note", Value: "first second"}, {Token: "Refs", Value: "#1"}},
+		},
+		{
+			name: "last paragraph with less than 25% trailers",
+			args: args{message: "feat: subject\n\nsome prose\nmore prose\nRefs: #1"},
+			want: []Trailer{},
+		},
+		{
+			name: "git generated trailer with 25% trailers",
+			args: args{message: "feat: subject\n\nsome prose\nmore prose\nthird line\nSigned-off-by: A <a@b.c>"},
+			want: []Trailer{
+				{Value: "some prose"},
+				{Value: "more prose"},
+				{Value: "third line"},
+				{Token: "Signed-off-by", Value: "A <a@b.c>"},
+			},
+		},
+		{
+			name: "configured separators",
+			args: args{message: "feat: subject\n\nBug #42\nRefs: #1", opts: TrailerOptions{Separators: ":#"}},
+			want: []Trailer{{Token: "Bug", Value: "42"}, {Token: "Refs", Value: "#1"}},
+		},
+		{
+			name: "configured key",
+			args: args{message: "feat: subject\n\nack: Z", opts: TrailerOptions{Keys: []TrailerKeyConfig{{Name: "ack", Key: "Acked-by"}}}},
+			want: []Trailer{{Token: "Acked-by", Value: "Z"}},
+		},
+		{
+			name: "ignore comments and patch",
+			args: args{message: "feat: subject\n\nRefs: #1\n# comment\n\n---\nRefs: #2\n"},
+			want: []Trailer{{Token: "Refs", Value: "#1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseTrailers(tt.args.message, tt.args.opts))
+		})
+	}
+}
+
+type interpretTrailersCase struct {
+	name    string
+	message string
+	opts    TrailerOptions
+	args    []TrailerArg
+	want    string
+}
+
+var interpretTrailersCases = []interpretTrailersCase{
+	{
+		name:    "no trailers",
+		message: "subject\n",
+		want:    "subject\n\n",
+	},
+	{
+		name:    "add to message without trailers",
+		message: "subject\n\nbody\n",
+		args:    []TrailerArg{{Trailer: "Refs=#1"}},
+		want:    "subject\n\nbody\n\nRefs: #1\n",
+	},
+	{
+		name:    "normalize existing trailers",
+		message: "subject\n\nRefs :  #1\n",
+		want:    "subject\n\nRefs: #1\n",
+	},
+	{
+		name:    "add if different neighbor",
+		message: "subject\n\nRefs: #1\n",
+		args:    []TrailerArg{{Trailer: "Refs: #1"}, {Trailer: "refs: #2"}},
+		want:    "subject\n\nRefs: #1\nrefs: #2\n",
+	},
+	{
+		name:    "add if different",
+		message: "subject\n\nRefs: #1\nAcked-by: Z\n",
+		args:    []TrailerArg{{Trailer: "Refs: #1", IfExists: TrailerIfExistsAddIfDifferent}},
+		want:    "subject\n\nRefs: #1\nAcked-by: Z\n",
+	},
+	{
+		name:    "add",
+		message: "subject\n\nRefs: #1\nAcked-by: Z\n",
+		args:    []TrailerArg{{Trailer: "Refs: #1", IfExists: TrailerIfExistsAdd, Where: TrailerWhereAfter}},
+		want:    "subject\n\nRefs: #1\nRefs: #1\nAcked-by: Z\n",
+	},
+	{
+		name:    "replace",
+		message: "subject\n\nRefs: #1\nAcked-by: Z\n",
+		args:    []TrailerArg{{Trailer: "Refs: #2", IfExists: TrailerIfExistsReplace}},
+		want:    "subject\n\nAcked-by: Z\nRefs: #2\n",
+	},
+	{
+		name:    "replace before",
+		message: "subject\n\nAcked-by: Z\nRefs: #1\n",
+		args:    []TrailerArg{{Trailer: "Refs: #2", IfExists: TrailerIfExistsReplace, Where: TrailerWhereBefore}},
+		want:    "subject\n\nAcked-by: Z\nRefs: #2\n",
+	},
+	{
+		name:    "do nothing",
+		message: "subject\n\nRefs: #1\n",
+		args:    []TrailerArg{{Trailer: "Refs: #2", IfExists: TrailerIfExistsDoNothing}},
+		want:    "subject\n\nRefs: #1\n",
+	},
+	{
+		name:    "missing do nothing",
+		message: "subject\n\nRefs: #1\n",
+		args:    []TrailerArg{{Trailer: "Acked-by: Z", IfMissing: TrailerIfMissingDoNothing}},
+		want:    "subject\n\nRefs: #1\n",
+	},
+	{
+		name:    "start",
+		message: "subject\n\nRefs: #1\n",
+		args:    []TrailerArg{{Trailer: "Acked-by: Z", Where: TrailerWhereStart}},
+		want:    "subject\n\nAcked-by: Z\nRefs: #1\n",
+	},
+	{
+		name:    "before",
+		message: "subject\n\nAcked-by: Y\nRefs: #1\n",
+		args:    []TrailerArg{{Trailer: "Refs: #2", Where: TrailerWhereBefore}},
+		want:    "subject\n\nAcked-by: Y\nRefs: #2\nRefs: #1\n",
+	},
+	{
+		name:    "global where",
+		message: "subject\n\nRefs: #1\nAcked-by: Y\n",
+		opts:    TrailerOptions{Where: TrailerWhereAfter, IfExists: TrailerIfExistsAdd},
+		args:    []TrailerArg{{Trailer: "Refs: #2"}},
+		want:    "subject\n\nRefs: #1\nRefs: #2\nAcked-by: Y\n",
+	},
+	{
+		name:    "configured key",
+		message: "subject\n\nRefs: #1\n",
+		opts:    TrailerOptions{Keys: []TrailerKeyConfig{{Name: "sign", Key: "Signed-off-by", Where: TrailerWhereStart}}},
+		args:    []TrailerArg{{Trailer: "sign=A <a@b.c>"}},
+		want:    "subject\n\nSigned-off-by: A <a@b.c>\nRefs: #1\n",
+	},
+	{
+		name:    "key ending with separator",
+		message: "subject\n",
+		opts:    TrailerOptions{Separators: ":#", Keys: []TrailerKeyConfig{{Name: "bug", Key: "Bug #"}}},
+		args:    []TrailerArg{{Trailer: "bug=42"}},
+		want:    "subject\n\nBug #42\n",
+	},
+	{
+		name:    "trailers before divider",
+		message: "subject\n\nRefs: #1\n---\ndiff\n",
+		args:    []TrailerArg{{Trailer: "Acked-by: Z"}},
+		want:    "subject\n\nRefs: #1\nAcked-by: Z\n---\ndiff\n",
+	},
+	{
+		name:    "trailers before comments",
+		message: "subject\n\n# Please enter the commit message\n",
+		args:    []TrailerArg{{Trailer: "Acked-by: Z"}},
+		want:    "subject\n\nAcked-by: Z\n\n# Please enter the commit message\n",
+	},
+	{
+		name:    "unfold and only trailers",
+		message: "subject\n\nbody\n\nNote: a\n b\nRefs: #1\n",
+		opts:    TrailerOptions{Unfold: true, OnlyTrailers: true, OnlyInput: true},
+		want:    "Note: a b\nRefs: #1\n",
+	},
+	{
+		name:    "trim empty",
+		message: "subject\n\nRefs:\nAcked-by: Z\n",
+		opts:    TrailerOptions{TrimEmpty: true},
+		want:    "subject\n\nAcked-by: Z\n",
+	},
+	{
+		name:    "no trailing newline",
+		message: "feat: x\n\nbody",
+		args:    []TrailerArg{{Trailer: "Refs: 2"}},
+		want:    "feat: x\n\nbody\nRefs: 2\n",
+	},
+	{
+		name:    "subject without trailing newline",
+		message: "subject",
+		want:    "subject\n",
+	},
+	{
+		name:    "trailers without trailing newline",
+		message: "feat: x\n\nbody\n\nNote: a\n b",
+		args:    []TrailerArg{{Trailer: "Refs: 2"}},
+		want:    "feat: x\n\nbody\n\nNote: a\n b\nRefs: 2\n",
+	},
+	{
+		name:    "divider without trailing newline",
+		message: "subject\n\nRefs: #1\n---\ndiff",
+		args:    []TrailerArg{{Trailer: "Acked-by: Z"}},
+		want:    "subject\n\nRefs: #1\nAcked-by: Z\n---\ndiff",
+	},
+	{
+		name:    "comments without trailing newline",
+		message: "subject\n\n# comment",
+		args:    []TrailerArg{{Trailer: "Acked-by: Z"}},
+		want:    "subject\n\nAcked-by: Z\n\n# comment",
+	},
+}
+
+func TestInterpretTrailers(t *testing.T) {
+	for _, tt := range interpretTrailersCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpretTrailers(tt.message, tt.opts, tt.args...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInterpretTrailersEmptyToken(t *testing.T) {
+	_, err := InterpretTrailers("subject\n", TrailerOptions{}, TrailerArg{Trailer: ": value"})
+	assert.ErrorIs(t, err, ErrEmptyTrailerToken)
+}
+
+func gitInterpretTrailers(t *testing.T, tt interpretTrailersCase) string {
+	args := []string{"-c", "trailer.separators=" + tt.opts.separators()}
+
+	if tt.opts.Where != "" {
+		args = append(args, "-c", "trailer.where="+string(tt.opts.Where))
+	}
+	if tt.opts.IfExists != "" {
+		args = append(args, "-c", "trailer.ifexists="+string(tt.opts.IfExists))
+	}
+	if tt.opts.IfMissing != "" {
+		args = append(args, "-c", "trailer.ifmissing="+string(tt.opts.IfMissing))
+	}
+	for _, key := range tt.opts.Keys {
+		args = append(args, "-c", "trailer."+key.Name+".key="+key.Key)
+		if key.Where != "" {
+			args = append(args, "-c", "trailer."+key.Name+".where="+string(key.Where))
+		}
+	}
+
+	args = append(args, "interpret-trailers")
+
+	flags := map[string]bool{
+		"--no-divider":    tt.opts.NoDivider,
+		"--unfold":        tt.opts.Unfold,
+		"--trim-empty":    tt.opts.TrimEmpty,
+		"--only-trailers": tt.opts.OnlyTrailers,
+		"--only-input":    tt.opts.OnlyInput,
+	}
+	for flag, enabled := range flags {
+		if enabled {
+			args = append(args, flag)
+		}
+	}
+
+	for _, arg := range tt.args {
+		if arg.Where != "" {
+			args = append(args, "--where", string(arg.Where))
+		}
+		if arg.IfExists != "" {
+			args = append(args, "--if-exists", string(arg.IfExists))
+		}
+		if arg.IfMissing != "" {
+			args = append(args, "--if-missing", string(arg.IfMissing))
+		}
+		args = append(args, "--trailer", arg.Trailer)
+		args = append(args, "--no-where", "--no-if-exists", "--no-if-missing")
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = strings.NewReader(tt.message)
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_NOSYSTEM=1", "GIT_CONFIG_GLOBAL="+os.DevNull)
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git interpret-trailers: %v", err)
+	}
+
+	return string(out)
+}
+
+// Compare with the output of git itself when it is available
+func TestInterpretTrailersMatchesGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	for _, tt := range interpretTrailersCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpretTrailers(tt.message, tt.opts, tt.args...)
+			assert.NoError(t, err)
+			assert.Equal(t, gitInterpretTrailers(t, tt), got)
+		})
+	}
+}
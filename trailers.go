@@ -0,0 +1,240 @@
+package conventionalcommitparser
+
+import (
+	"strings"
+)
+
+// Trailers is a view of the footers of a message, keyed like http.Header
+// but keeping the order in which they appear in the message.
+// Keys are case-insensitive, see CanonicalTrailerKey.
+// The message is the only storage: the trailers are read from its Footer,
+// computed once along with Message.Parsed, and the edits replace its Footer.
+type Trailers struct {
+	m *Message
+}
+
+// Trailers returns the trailers of the message
+func (m *Message) Trailers() Trailers {
+	return Trailers{m: m}
+}
+
+type trailerEntry struct {
+	key       string
+	token     string
+	separator string
+	value     string
+}
+
+// CanonicalTrailerKey returns the canonical format of a trailer key:
+// spaces become dashes and only the first letter is upper case,
+// so `BREAKING CHANGE` and `breaking-change` are both `Breaking-change`.
+func CanonicalTrailerKey(key string) string {
+	if isCanonicalTrailerKey(key) {
+		return key
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	key = strings.Join(strings.Fields(key), "-")
+
+	if key == "" {
+		return ""
+	}
+
+	return strings.ToUpper(key[:1]) + key[1:]
+}
+
+// isCanonicalTrailerKey reports whether the key is already canonical, as
+// most keys are, `Reviewed-by`
+func isCanonicalTrailerKey(key string) bool {
+	if key == "" || key[0] < 'A' || key[0] > 'Z' {
+		return false
+	}
+
+	for i := 1; i < len(key); i++ {
+		if c := key[i]; (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newTrailerEntry(raw string) trailerEntry {
+	txt := strings.ReplaceAll(raw, "\r\n", "\n")
+	line := txt
+
+	if i := strings.IndexByte(txt, '\n'); i != -1 {
+		line = txt[:i]
+	}
+
+	kind, _, _ := scanFooter(line)
+	footer := paseFooterParagraph(line)
+	separator := ": "
+
+	if kind != footerBreakingChange && kind != footerToken {
+		separator = " "
+	}
+
+	// the value is the title followed by the next lines, as written
+	// unless spaces follow the title
+	value := footer.Title
+	if len(line) != len(txt) {
+		if strings.HasSuffix(line, footer.Title) {
+			value = strings.TrimSpace(txt[len(line)-len(footer.Title):])
+		} else {
+			value = strings.TrimSpace(footer.Title + txt[len(line):])
+		}
+	}
+
+	return trailerEntry{
+		key:       CanonicalTrailerKey(footer.Tag),
+		token:     footer.Tag,
+		separator: separator,
+		value:     value,
+	}
+}
+
+func newTrailerEntries(footers []string) []trailerEntry {
+	entries := make([]trailerEntry, 0, len(footers))
+
+	for _, f := range footers {
+		entries = append(entries, newTrailerEntry(f))
+	}
+
+	return entries
+}
+
+// entries returns the trailers of the message, one for each footer
+func (t Trailers) entries() []trailerEntry {
+	return t.m.Parsed().trailers
+}
+
+// Get returns the first value associated with the key
+func (t Trailers) Get(key string) string {
+	key = CanonicalTrailerKey(key)
+
+	for _, e := range t.entries() {
+		if e.key == key {
+			return e.value
+		}
+	}
+
+	return ""
+}
+
+// Values returns all the values associated with the key
+func (t Trailers) Values(key string) []string {
+	key = CanonicalTrailerKey(key)
+	values := make([]string, 0)
+
+	for _, e := range t.entries() {
+		if e.key == key {
+			values = append(values, e.value)
+		}
+	}
+
+	return values
+}
+
+func (t Trailers) Has(key string) bool {
+	key = CanonicalTrailerKey(key)
+
+	for _, e := range t.entries() {
+		if e.key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Keys returns the canonical keys in the order they first appear
+func (t Trailers) Keys() []string {
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, e := range t.entries() {
+		if !seen[e.key] {
+			seen[e.key] = true
+			keys = append(keys, e.key)
+		}
+	}
+
+	return keys
+}
+
+// Add appends a trailer after all the others
+func (t Trailers) Add(key, value string) {
+	footer := make([]string, 0, len(t.m.Footer)+1)
+	footer = append(footer, t.m.Footer...)
+
+	t.m.Footer = append(footer, strings.TrimSpace(key)+": "+value)
+}
+
+// Set replaces the value of the first trailer with the key, in place,
+// and removes the others. The trailer is appended if the key is missing.
+func (t Trailers) Set(key, value string) {
+	canonical := CanonicalTrailerKey(key)
+	footer := make([]string, 0, len(t.m.Footer))
+	found := false
+
+	for i, e := range t.entries() {
+		switch {
+		case e.key != canonical:
+			footer = append(footer, t.m.Footer[i])
+		case !found:
+			found = true
+			footer = append(footer, e.token+e.separator+value)
+		}
+	}
+
+	t.m.Footer = footer
+
+	if !found {
+		t.Add(key, value)
+	}
+}
+
+// Del removes all the trailers with the key
+func (t Trailers) Del(key string) {
+	key = CanonicalTrailerKey(key)
+	footer := make([]string, 0, len(t.m.Footer))
+
+	for i, e := range t.entries() {
+		if e.key != key {
+			footer = append(footer, t.m.Footer[i])
+		}
+	}
+
+	t.m.Footer = footer
+}
+
+func (t Trailers) Len() int {
+	return len(t.m.Footer)
+}
+
+// String renders the trailers, see joinFooters
+func (t Trailers) String() string {
+	return joinFooters(t.m.Footer)
+}
+
+// joinFooters renders footers one per line, or after a blank line when the
+// previous footer has several paragraphs, so that the rendered message
+// parses to the same footers
+func joinFooters(footers []string) string {
+	var b strings.Builder
+
+	for i, f := range footers {
+		switch {
+		case i == 0:
+		case strings.Contains(strings.ReplaceAll(footers[i-1], "\r\n", "\n"), "\n\n"):
+			b.WriteString("\n\n")
+		default:
+			b.WriteString("\n")
+		}
+
+		b.WriteString(f)
+	}
+
+	return b.String()
+}
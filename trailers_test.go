@@ -0,0 +1,171 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalTrailerKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "refs", want: "Refs"},
+		{key: "Signed-Off-By", want: "Signed-off-by"},
+		{key: "BREAKING CHANGE", want: "Breaking-change"},
+		{key: "BREAKING-CHANGE", want: "Breaking-change"},
+		{key: "  closes ", want: "Closes"},
+		{key: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, CanonicalTrailerKey(tt.key))
+		})
+	}
+}
+
+const trailersMessage = `feat: support xxx
+
+BREAKING CHANGE: rename
+
+first paragraph
+
+Reviewed-by: A
+Closes #1, #2
+reviewed-by: B`
+
+func TestTrailers(t *testing.T) {
+	trailers := Parse(trailersMessage).Trailers()
+
+	assert.Equal(t, []string{"Breaking-change", "Reviewed-by", "Closes"}, trailers.Keys())
+	assert.Equal(t, "rename\n\nfirst paragraph", trailers.Get("breaking change"))
+	assert.Equal(t, "A", trailers.Get("REVIEWED-BY"))
+	assert.Equal(t, []string{"A", "B"}, trailers.Values("Reviewed-by"))
+	assert.Equal(t, "#1, #2", trailers.Get("closes"))
+	assert.True(t, trailers.Has("Closes"))
+	assert.False(t, trailers.Has("Refs"))
+	assert.Equal(t, "", trailers.Get("Refs"))
+	assert.Equal(t, []string{}, trailers.Values("Refs"))
+}
+
+func TestTrailersEdit(t *testing.T) {
+	msg := Parse(trailersMessage)
+
+	msg.Trailers().Set("reviewed-by", "C")
+	msg.Trailers().Set("Closes", "#3")
+	msg.Trailers().Add("Refs", "#4")
+	msg.Trailers().Add("refs", "#5")
+	msg.Trailers().Set("Acked-by", "D")
+
+	assert.Equal(t, []string{"C"}, msg.Trailers().Values("Reviewed-by"))
+	assert.Equal(t, []string{"#4", "#5"}, msg.Trailers().Values("Refs"))
+
+	assert.Equal(t, `feat: support xxx
+
+BREAKING CHANGE: rename
+
+first paragraph
+
+Reviewed-by: C
+Closes #3
+Refs: #4
+refs: #5
+Acked-by: D`, msg.String())
+
+	reparsed := Parse(msg.String())
+	assert.Equal(t, msg.Trailers().Keys(), reparsed.Trailers().Keys())
+	assert.Equal(t, msg.String(), reparsed.String())
+
+	msg.Trailers().Del("REFS")
+	msg.Trailers().Del("Breaking-Change")
+
+	assert.Equal(t, []string{"Reviewed-by", "Closes", "Acked-by"}, msg.Trailers().Keys())
+	assert.Equal(t, "Reviewed-by: C\nCloses #3\nAcked-by: D", msg.Trailers().String())
+}
+
+func TestTrailersEditFooter(t *testing.T) {
+	msg := Parse("feat: x\n\nbody\n\nReviewed-by: Z\nRefs: #1")
+
+	msg.Trailers().Set("Reviewed-by", "Y")
+	assert.Equal(t, []string{"Reviewed-by: Y", "Refs: #1"}, msg.Footer)
+	assert.Equal(t, "Y", msg.GetFooterByField("Reviewed-by").Title)
+	assert.Equal(t, "Y", msg.Parsed().Footers[0].Title)
+
+	msg.Trailers().Add("Closes", "#2")
+	msg.Trailers().Del("Refs")
+	assert.Equal(t, []string{"Reviewed-by: Y", "Closes: #2"}, msg.Footer)
+	assert.Equal(t, msg.String(), Parse(msg.String()).String())
+}
+
+func TestTrailersStringSeparators(t *testing.T) {
+	message := "feat: x\n\nbody\n\nRefs: #1\nnote line\nReviewed-by: Z"
+	msg := Parse(message)
+
+	assert.Equal(t, []string{"Refs: #1\nnote line", "Reviewed-by: Z"}, msg.Footer)
+	assert.Equal(t, message, msg.String())
+
+	// the footers are rendered from the fields, one per line
+	msg = Parse("feat: x\n\nRefs: #1\n\nCloses #2\nAcked-by: A")
+	assert.Equal(t, "feat: x\n\nRefs: #1\nCloses #2\nAcked-by: A", msg.String())
+
+	msg.Trailers().Del("Closes")
+	assert.Equal(t, "Refs: #1\nAcked-by: A", msg.Trailers().String())
+}
+
+func TestTrailersCopies(t *testing.T) {
+	msg := Parse("feat: x\n\nRefs: #1\nRefs: #2\nFoo: x")
+	trailers := msg.Trailers()
+	trailers.Del("Refs")
+
+	assert.Equal(t, []string{"Foo: x"}, msg.Footer)
+	assert.Equal(t, []string{"Foo"}, msg.Trailers().Keys())
+	assert.Equal(t, "feat: x\n\nFoo: x", msg.String())
+
+	copied := *msg
+	copied.Trailers().Add("Refs", "#3")
+
+	assert.Equal(t, []string{"Foo: x", "Refs: #3"}, copied.Footer)
+	assert.Equal(t, []string{"Foo: x"}, msg.Footer)
+	assert.Equal(t, "feat: x\n\nFoo: x", msg.String())
+}
+
+func TestTrailersLiteralMessage(t *testing.T) {
+	msg := &Message{Header: "fix: x", Body: "body", Footer: []string{"Refs: #1"}}
+
+	assert.Equal(t, "fix: x\n\nbody\n\nRefs: #1", msg.String())
+	assert.Equal(t, "#1", msg.Trailers().Get("refs"))
+
+	msg.Footer = append(msg.Footer, "Closes #2")
+	assert.Equal(t, "fix: x\n\nbody\n\nRefs: #1\nCloses #2", msg.String())
+	assert.Equal(t, []string{"Refs", "Closes"}, msg.Trailers().Keys())
+}
+
+func TestMessageString(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "header only",
+			message: "feat: support xxx",
+			want:    "feat: support xxx",
+		},
+		{
+			name:    "header and body",
+			message: "feat: support xxx\n\nbody",
+			want:    "feat: support xxx\n\nbody",
+		},
+		{
+			name:    "header and footer",
+			message: "feat: support xxx\n\nRefs: #1\nCloses #2",
+			want:    "feat: support xxx\n\nRefs: #1\nCloses #2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.message).String())
+		})
+	}
+}
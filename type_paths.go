@@ -0,0 +1,168 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathClass is the kind of a file changed by a commit
+type PathClass string
+
+const (
+	PathSource PathClass = "source"
+	PathTest   PathClass = "test"
+	PathDocs   PathClass = "docs"
+	PathBuild  PathClass = "build"
+	PathCI     PathClass = "ci"
+	// PathIgnored files are left out of the checks, a generated CHANGELOG.md
+	PathIgnored PathClass = "ignored"
+)
+
+// PathClassifier gives a class to the paths matching a glob, see ScopeDefinition.Paths
+type PathClassifier struct {
+	Pattern string    `yaml:"pattern" json:"pattern"`
+	Class   PathClass `yaml:"class" json:"class"`
+}
+
+// TypePathsOptions configure TypePathsRules
+type TypePathsOptions struct {
+	// Paths classify the paths before the default classification,
+	// the first matching glob wins
+	Paths []PathClassifier
+	// Types resolve the aliases of the types, DefaultTypeRegistry when nil
+	Types *TypeRegistry
+}
+
+func (o TypePathsOptions) types() *TypeRegistry {
+	if o.Types == nil {
+		return DefaultTypeRegistry
+	}
+
+	return o.Types
+}
+
+// Classify returns the class of a path: the class of the first matching
+// glob, else tests, docs, build and CI files are told by their names and
+// directories, `a_test.go` or `docs/**`, and the rest is source code
+func (o TypePathsOptions) Classify(p string) PathClass {
+	for _, c := range o.Paths {
+		if matchPathGlob(c.Pattern, p) {
+			return c.Class
+		}
+	}
+
+	if t := pathType(p); t != "" {
+		return PathClass(t)
+	}
+
+	return PathSource
+}
+
+// classify returns the paths of the commit by class, without the ignored ones
+func (o TypePathsOptions) classify(c *Commit) map[PathClass][]string {
+	classes := make(map[PathClass][]string)
+
+	for _, p := range c.Paths {
+		if class := o.Classify(p); class != PathIgnored {
+			classes[class] = append(classes[class], p)
+		}
+	}
+
+	return classes
+}
+
+// onlyClasses reports whether the paths all belong to the given classes
+func onlyClasses(classes map[PathClass][]string, allowed ...PathClass) bool {
+	for class := range classes {
+		found := false
+
+		for _, a := range allowed {
+			found = found || class == a
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatPaths lists the first paths, `a.go, b.go and 3 more`
+func formatPaths(paths []string) string {
+	const max = 3
+
+	if len(paths) <= max {
+		return strings.Join(paths, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:max], ", "), len(paths)-max)
+}
+
+// typePathsRule builds a rule checking the commits of the types whose paths
+// are known
+func typePathsRule(name string, opts TypePathsOptions, types []string, check func(header Header, classes map[PathClass][]string) string) LintRule {
+	return LintRule{
+		Name: name,
+		Check: func(c *Commit) []string {
+			if len(c.Paths) == 0 {
+				return nil
+			}
+
+			header := c.Message.ParseHeader()
+			t := opts.types().Canonical(header.Type)
+
+			for _, candidate := range types {
+				if t == candidate {
+					if message := check(header, opts.classify(c)); message != "" {
+						return []string{message}
+					}
+
+					return nil
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// TypePathsRules cross-check the type of the commits against the paths
+// they change, when known, see Commit.Paths:
+//
+//	type-docs-paths: docs commits only change documentation
+//	type-feat-fix-paths: feat, fix and perf commits change more than tests and documentation
+//	type-test-paths: test commits only change tests
+//	type-build-paths: build commits change build files
+func TypePathsRules(opts TypePathsOptions) []LintRule {
+	return []LintRule{
+		typePathsRule("type-docs-paths", opts, []string{"docs"}, func(header Header, classes map[PathClass][]string) string {
+			if paths := append(append([]string{}, classes[PathSource]...), classes[PathBuild]...); len(paths) != 0 {
+				return fmt.Sprintf("%s commit changes code (%s), docs commits do not bump the version so the change would be left out of the release notes", header.Type, formatPaths(paths))
+			}
+
+			return ""
+		}),
+		typePathsRule("type-feat-fix-paths", opts, []string{"feat", "fix", "perf"}, func(header Header, classes map[PathClass][]string) string {
+			if len(classes) != 0 && onlyClasses(classes, PathTest, PathDocs) {
+				return fmt.Sprintf("%s commit only changes tests or documentation, it would bump the version without changing the code, use test or docs", header.Type)
+			}
+
+			return ""
+		}),
+		typePathsRule("type-test-paths", opts, []string{"test"}, func(header Header, classes map[PathClass][]string) string {
+			if paths := classes[PathSource]; len(paths) != 0 {
+				return fmt.Sprintf("%s commit changes code other than tests (%s), use the type of the code change", header.Type, formatPaths(paths))
+			}
+
+			return ""
+		}),
+		typePathsRule("type-build-paths", opts, []string{"build"}, func(header Header, classes map[PathClass][]string) string {
+			if len(classes) != 0 && len(classes[PathBuild]) == 0 {
+				return fmt.Sprintf("%s commit changes no build file, use the type of the change", header.Type)
+			}
+
+			return ""
+		}),
+	}
+}
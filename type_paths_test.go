@@ -0,0 +1,64 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypePathsRules(t *testing.T) {
+	rules := TypePathsRules(TypePathsOptions{
+		Paths: []PathClassifier{
+			{Pattern: "CHANGELOG.md", Class: PathIgnored},
+			{Pattern: "examples/**", Class: PathDocs},
+		},
+	})
+
+	tests := []struct {
+		message string
+		paths   []string
+		want    []LintProblem
+	}{
+		{"docs: install", []string{"README.md", "docs/install.md", "examples/main.go"}, []LintProblem{}},
+		{"docs: install", []string{"README.md", "parser.go", "go.mod"}, []LintProblem{
+			{Rule: "type-docs-paths", Message: "docs commit changes code (parser.go, go.mod), docs commits do not bump the version so the change would be left out of the release notes"},
+		}},
+		{"doc: install", []string{"a.go", "b.go", "c.go", "d.go", "e.go"}, []LintProblem{
+			{Rule: "type-docs-paths", Message: "doc commit changes code (a.go, b.go, c.go and 2 more), docs commits do not bump the version so the change would be left out of the release notes"},
+		}},
+		{"feat: search", []string{"search.go", "search_test.go", "CHANGELOG.md"}, []LintProblem{}},
+		{"fix: search", []string{"search_test.go", "docs/search.md", "CHANGELOG.md"}, []LintProblem{
+			{Rule: "type-feat-fix-paths", Message: "fix commit only changes tests or documentation, it would bump the version without changing the code, use test or docs"},
+		}},
+		{"feature!: search", []string{"README.md"}, []LintProblem{
+			{Rule: "type-feat-fix-paths", Message: "feature commit only changes tests or documentation, it would bump the version without changing the code, use test or docs"},
+		}},
+		{"fix: changelog", []string{"CHANGELOG.md"}, []LintProblem{}},
+		{"test: search", []string{"search_test.go", "testdata/q.txt"}, []LintProblem{}},
+		{"test: search", []string{"search_test.go", "search.go"}, []LintProblem{
+			{Rule: "type-test-paths", Message: "test commit changes code other than tests (search.go), use the type of the code change"},
+		}},
+		{"build: go 1.17", []string{"go.mod", "Makefile"}, []LintProblem{}},
+		{"build: go 1.17", []string{"parser.go"}, []LintProblem{
+			{Rule: "type-build-paths", Message: "build commit changes no build file, use the type of the change"},
+		}},
+		{"chore: anything", []string{"parser.go"}, []LintProblem{}},
+		{"Fix search", []string{"README.md"}, []LintProblem{}},
+		// unknown paths are not checked
+		{"docs: install", nil, []LintProblem{}},
+	}
+
+	for _, test := range tests {
+		c := &Commit{Message: Parse(test.message), Paths: test.paths}
+		assert.Equal(t, test.want, Lint(c, rules...), test.message)
+	}
+}
+
+func TestTypePathsOptionsClassify(t *testing.T) {
+	opts := TypePathsOptions{Paths: []PathClassifier{{Pattern: "scripts/**", Class: PathBuild}}}
+
+	assert.Equal(t, PathBuild, opts.Classify("scripts/release.sh"))
+	assert.Equal(t, PathCI, opts.Classify(".github/workflows/ci.yml"))
+	assert.Equal(t, PathTest, opts.Classify("pkg/a_test.go"))
+	assert.Equal(t, PathSource, opts.Classify("pkg/a.go"))
+}
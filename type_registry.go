@@ -0,0 +1,177 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TypeDefinition declares a type of the registry
+type TypeDefinition struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Aliases     []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	// Bump is the semver impact of a non breaking commit of this type
+	Bump Bump `yaml:"bump,omitempty" json:"bump,omitempty"`
+	// Section is the title of the changelog section
+	Section string `yaml:"section,omitempty" json:"section,omitempty"`
+	Emoji   string `yaml:"emoji,omitempty" json:"emoji,omitempty"`
+	// Hidden types are left out of changelogs
+	Hidden bool `yaml:"hidden,omitempty" json:"hidden,omitempty"`
+}
+
+// TypeRegistry is the vocabulary of types allowed in headers.
+// The order of the types is the order of the changelog sections.
+//
+//	types:
+//	  - name: feat
+//	    description: A new feature
+//	    aliases: [feature]
+//	    bump: minor
+//	    section: Features
+//	    emoji: ✨
+type TypeRegistry struct {
+	Types []TypeDefinition `yaml:"types" json:"types"`
+}
+
+// DefaultTypeRegistry follows the types of the Conventional Commits
+// and the sections of conventional-changelog
+var DefaultTypeRegistry = &TypeRegistry{
+	Types: []TypeDefinition{
+		{Name: "feat", Description: "A new feature", Aliases: []string{"feature"}, Bump: BumpMinor, Section: "Features", Emoji: "✨"},
+		{Name: "fix", Description: "A bug fix", Aliases: []string{"bugfix", "bug"}, Bump: BumpPatch, Section: "Bug Fixes", Emoji: "🐛"},
+		{Name: "perf", Description: "A code change that improves performance", Aliases: []string{"performance"}, Bump: BumpPatch, Section: "Performance Improvements", Emoji: "⚡️"},
+		{Name: "revert", Description: "Reverts a previous commit", Bump: BumpPatch, Section: "Reverts", Emoji: "⏪"},
+		{Name: "docs", Description: "Documentation only changes", Aliases: []string{"doc"}, Section: "Documentation", Emoji: "📝", Hidden: true},
+		{Name: "style", Description: "Changes that do not affect the meaning of the code", Section: "Styles", Emoji: "💄", Hidden: true},
+		{Name: "refactor", Description: "A code change that neither fixes a bug nor adds a feature", Section: "Code Refactoring", Emoji: "♻️", Hidden: true},
+		{Name: "test", Description: "Adding missing tests or correcting existing tests", Aliases: []string{"tests"}, Section: "Tests", Emoji: "✅", Hidden: true},
+		{Name: "build", Description: "Changes that affect the build system or external dependencies", Section: "Build System", Emoji: "📦", Hidden: true},
+		{Name: "ci", Description: "Changes to the CI configuration files and scripts", Section: "Continuous Integration", Emoji: "👷", Hidden: true},
+		{Name: "chore", Description: "Other changes that don't modify src or test files", Section: "Chores", Emoji: "🔧", Hidden: true},
+	},
+}
+
+// ParseTypeRegistry parses a YAML or JSON registry
+func ParseTypeRegistry(data []byte) (*TypeRegistry, error) {
+	registry := TypeRegistry{}
+
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("parse type registry: %w", err)
+	}
+
+	for i, t := range registry.Types {
+		if strings.TrimSpace(t.Name) == "" {
+			return nil, fmt.Errorf("parse type registry: type #%d has no name", i+1)
+		}
+	}
+
+	return &registry, nil
+}
+
+// LoadTypeRegistry reads a YAML or JSON registry file
+func LoadTypeRegistry(filepath string) (*TypeRegistry, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseTypeRegistry(data)
+}
+
+// Lookup returns the definition of a type or one of its aliases, case-insensitively
+func (r *TypeRegistry) Lookup(name string) *TypeDefinition {
+	name = strings.TrimSpace(name)
+
+	for i := range r.Types {
+		t := &r.Types[i]
+
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+
+		for _, alias := range t.Aliases {
+			if strings.EqualFold(alias, name) {
+				return t
+			}
+		}
+	}
+
+	return nil
+}
+
+// Canonical returns the registered name of a type or alias,
+// unknown types are returned unchanged
+func (r *TypeRegistry) Canonical(name string) string {
+	if t := r.Lookup(name); t != nil {
+		return t.Name
+	}
+
+	return name
+}
+
+// Index returns the position of the type in the registry, or -1
+func (r *TypeRegistry) Index(name string) int {
+	if t := r.Lookup(name); t != nil {
+		for i := range r.Types {
+			if &r.Types[i] == t {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// Suggest returns the registered type closest to a misspelled one, or an empty string
+func (r *TypeRegistry) Suggest(name string) string {
+	best := ""
+	bestDistance := 0
+	name = strings.ToLower(name)
+
+	for _, t := range r.Types {
+		for _, candidate := range append([]string{t.Name}, t.Aliases...) {
+			distance := levenshtein(name, strings.ToLower(candidate))
+
+			if distance <= 2 && distance < len(candidate) && (best == "" || distance < bestDistance) {
+				best = t.Name
+				bestDistance = distance
+			}
+		}
+	}
+
+	return best
+}
+
+// CanonicalType returns the type resolved against the registry, `feature`
+// is `feat`, see TypeRegistry.Canonical. The registry is DefaultTypeRegistry
+// when nil.
+func (h Header) CanonicalType(r *TypeRegistry) string {
+	if r == nil {
+		r = DefaultTypeRegistry
+	}
+
+	return r.Canonical(h.Type)
+}
+
+// TypeEnumRule reports the types missing from the registry
+func TypeEnumRule(r *TypeRegistry) LintRule {
+	return LintRule{
+		Name: "type-enum",
+		Check: func(c *Commit) []string {
+			header := c.Message.ParseHeader()
+
+			if header.Type == "" || r.Lookup(header.Type) != nil {
+				return nil
+			}
+
+			if suggestion := r.Suggest(header.Type); suggestion != "" {
+				return []string{fmt.Sprintf("type %q is not registered, did you mean %q?", header.Type, suggestion)}
+			}
+
+			return []string{fmt.Sprintf("type %q is not registered", header.Type)}
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package conventionalcommitparser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const typeRegistryYAML = `
+types:
+  - name: feat
+    description: A new feature
+    aliases: [feature, funktion]
+    bump: minor
+    section: Features
+    emoji: ✨
+  - name: fix
+    aliases: [bugfix]
+    bump: patch
+    section: Bug Fixes
+  - name: chore
+    hidden: true
+`
+
+func TestParseTypeRegistry(t *testing.T) {
+	registry, err := ParseTypeRegistry([]byte(typeRegistryYAML))
+	assert.NoError(t, err)
+
+	assert.Equal(t, TypeDefinition{
+		Name:        "feat",
+		Description: "A new feature",
+		Aliases:     []string{"feature", "funktion"},
+		Bump:        BumpMinor,
+		Section:     "Features",
+		Emoji:       "✨",
+	}, registry.Types[0])
+	assert.Equal(t, BumpNone, registry.Types[2].Bump)
+	assert.True(t, registry.Types[2].Hidden)
+
+	fromJSON, err := ParseTypeRegistry([]byte(`{"types": [{"name": "fix", "aliases": ["bugfix"], "bump": "patch", "section": "Bug Fixes"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Types[1], fromJSON.Types[0])
+
+	_, err = ParseTypeRegistry([]byte("types:\n  - bump: minor\n"))
+	assert.Error(t, err)
+
+	_, err = ParseTypeRegistry([]byte("types:\n  - name: feat\n    bump: huge\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadTypeRegistry(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "types.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(typeRegistryYAML), 0o644))
+
+	registry, err := LoadTypeRegistry(file)
+	assert.NoError(t, err)
+	assert.Len(t, registry.Types, 3)
+}
+
+func TestTypeRegistryLookup(t *testing.T) {
+	registry, err := ParseTypeRegistry([]byte(typeRegistryYAML))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "feat", registry.Canonical("feat"))
+	assert.Equal(t, "feat", registry.Canonical("Feature"))
+	assert.Equal(t, "feat", registry.Canonical("funktion"))
+	assert.Equal(t, "fix", registry.Canonical("bugfix"))
+	assert.Equal(t, "unknown", registry.Canonical("unknown"))
+	assert.Nil(t, registry.Lookup("unknown"))
+	assert.Equal(t, 1, registry.Index("bugfix"))
+	assert.Equal(t, -1, registry.Index("unknown"))
+	assert.Equal(t, "feat", registry.Suggest("faet"))
+	assert.Equal(t, "", registry.Suggest("documentation"))
+}
+
+func TestHeaderCanonicalType(t *testing.T) {
+	header := parseHeader("feature(api): add search")
+
+	assert.Equal(t, "feature", header.Type)
+	assert.Equal(t, "feat", header.CanonicalType(nil))
+	assert.Equal(t, "fix", parseHeader("bugfix: typo").CanonicalType(nil))
+	assert.Equal(t, "", parseHeader("common commit").CanonicalType(nil))
+
+	registry, err := ParseTypeRegistry([]byte(typeRegistryYAML))
+	assert.NoError(t, err)
+	assert.Equal(t, "feat", parseHeader("funktion: add search").CanonicalType(registry))
+	assert.Equal(t, "funktion", parseHeader("funktion: add search").CanonicalType(nil))
+}
+
+func TestTypeEnumRule(t *testing.T) {
+	rule := TypeEnumRule(DefaultTypeRegistry)
+
+	tests := []struct {
+		message string
+		want    []LintProblem
+	}{
+		{message: "feat: subject", want: []LintProblem{}},
+		{message: "feature: subject", want: []LintProblem{}},
+		{message: "common commit", want: []LintProblem{}},
+		{
+			message: "fxi: subject",
+			want:    []LintProblem{{Rule: "type-enum", Message: `type "fxi" is not registered, did you mean "fix"?`}},
+		},
+		{
+			message: "improvement: subject",
+			want:    []LintProblem{{Rule: "type-enum", Message: `type "improvement" is not registered`}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, Lint(&Commit{Message: Parse(tt.message)}, rule))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, `v1.2.3-rc.1`
+type Version struct {
+	Major, Minor, Patch int
+	// Prerelease without the dash, `rc.1`
+	Prerelease string
+}
+
+// ParseVersion parses a semantic version, with or without the `v` prefix.
+// The build metadata, `+build.5`, is ignored.
+func ParseVersion(txt string) (Version, error) {
+	v := Version{}
+	s := strings.TrimPrefix(strings.TrimSpace(txt), "v")
+
+	if i := strings.IndexByte(s, '+'); i != -1 {
+		s = s[:i]
+	}
+
+	if i := strings.IndexByte(s, '-'); i != -1 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+
+		if v.Prerelease == "" {
+			return Version{}, fmt.Errorf("invalid version %q", txt)
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q", txt)
+	}
+
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (len(part) > 1 && part[0] == '0') || part[0] == '+' {
+			return Version{}, fmt.Errorf("invalid version %q", txt)
+		}
+
+		*numbers[i] = n
+	}
+
+	return v, nil
+}
+
+// String returns the version with the `v` prefix of Go modules and git tags
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+
+	return s
+}
+
+// Next returns the version following a change of the given impact.
+// Breaking changes of 0.y.z versions bump the minor version, as 1.0.0 is
+// the promise of a stable API. A prerelease is released when it covers the
+// change, X.0.0-pre covers a major change and X.Y.0-pre a minor one,
+// otherwise its release is bumped: v1.3.0-rc.1 is followed by v2.0.0 after
+// a breaking change.
+func (v Version) Next(bump Bump) Version {
+	if bump == BumpNone {
+		return v
+	}
+
+	if bump == BumpMajor && v.Major == 0 {
+		bump = BumpMinor
+	}
+
+	if v.Prerelease != "" {
+		release := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+
+		if release.prereleaseCovers() >= bump {
+			return release
+		}
+
+		return release.Next(bump)
+	}
+
+	switch bump {
+	case BumpMajor:
+		return Version{Major: v.Major + 1}
+	case BumpMinor:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	default:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// prereleaseCovers returns the largest change a prerelease of the version covers
+func (v Version) prereleaseCovers() Bump {
+	switch {
+	case v.Minor == 0 && v.Patch == 0:
+		return BumpMajor
+	case v.Patch == 0:
+		return BumpMinor
+	}
+
+	return BumpPatch
+}
+
+// NextVersion returns the version following the commits, see BumpCommits
+func (r *TypeRegistry) NextVersion(current Version, commits []*Commit) Version {
+	return current.Next(r.BumpCommits(commits))
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or greater than
+// o, following the precedence of semantic versions: a prerelease is lower
+// than its release and its identifiers compare one by one
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := comparePrereleaseIdentifiers(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+
+	return sign(len(a) - len(b))
+}
+
+// comparePrereleaseIdentifiers compares numbers numerically, and lower than text
+func comparePrereleaseIdentifiers(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return sign(x - y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+
+	return 0
+}
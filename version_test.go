@@ -0,0 +1,60 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	for txt, want := range map[string]Version{
+		"v1.2.3":           {Major: 1, Minor: 2, Patch: 3},
+		"0.10.0":           {Minor: 10},
+		"v2.0.0-rc.1":      {Major: 2, Prerelease: "rc.1"},
+		"v1.0.0+build.5":   {Major: 1},
+		"v1.0.0-beta+exp1": {Major: 1, Prerelease: "beta"},
+	} {
+		v, err := ParseVersion(txt)
+		assert.NoError(t, err, txt)
+		assert.Equal(t, want, v, txt)
+	}
+
+	for _, txt := range []string{"", "v1", "v1.2", "v1.2.3.4", "v01.2.3", "v1.-2.3", "v1.+2.3", "v1.2.3-", "va.b.c"} {
+		_, err := ParseVersion(txt)
+		assert.Error(t, err, txt)
+	}
+
+	assert.Equal(t, "v2.0.0-rc.1", Version{Major: 2, Prerelease: "rc.1"}.String())
+}
+
+func TestVersionNext(t *testing.T) {
+	tests := []struct {
+		current string
+		bump    Bump
+		want    string
+	}{
+		{"v1.2.3", BumpNone, "v1.2.3"},
+		{"v1.2.3", BumpPatch, "v1.2.4"},
+		{"v1.2.3", BumpMinor, "v1.3.0"},
+		{"v1.2.3", BumpMajor, "v2.0.0"},
+		{"v0.2.3", BumpMajor, "v0.3.0"},
+		{"v0.2.3", BumpPatch, "v0.2.4"},
+		{"v2.0.0-rc.1", BumpPatch, "v2.0.0"},
+		{"v2.0.0-rc.1", BumpMajor, "v2.0.0"},
+		{"v1.3.0-rc.1", BumpMinor, "v1.3.0"},
+		{"v1.3.0-rc.1", BumpMajor, "v2.0.0"},
+		{"v1.3.1-rc.1", BumpPatch, "v1.3.1"},
+		{"v1.3.1-rc.1", BumpMinor, "v1.4.0"},
+		{"v0.3.0-rc.1", BumpMajor, "v0.3.0"},
+		{"v0.3.1-rc.1", BumpMajor, "v0.4.0"},
+	}
+
+	for _, test := range tests {
+		current, err := ParseVersion(test.current)
+		assert.NoError(t, err)
+		assert.Equal(t, test.want, current.Next(test.bump).String(), "%s %s", test.current, test.bump)
+	}
+
+	commits := []*Commit{{Message: Parse("fix: x")}, {Message: Parse("feat: y")}}
+	assert.Equal(t, Version{Major: 1, Minor: 3}, DefaultTypeRegistry.NextVersion(Version{Major: 1, Minor: 2, Patch: 3}, commits))
+}
@@ -0,0 +1,135 @@
+package conventionalcommitparser
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges are the East Asian Wide and Fullwidth characters
+var wideRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115F, Stride: 1}, // Hangul Jamo
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1}, // emoji presented as wide by default
+		{Lo: 0x23E9, Hi: 0x23EC, Stride: 1},
+		{Lo: 0x23F0, Hi: 0x23F0, Stride: 1},
+		{Lo: 0x23F3, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x25FD, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267F, Hi: 0x267F, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26A1, Hi: 0x26A1, Stride: 1},
+		{Lo: 0x26AA, Hi: 0x26AB, Stride: 1},
+		{Lo: 0x26BD, Hi: 0x26BE, Stride: 1},
+		{Lo: 0x26C4, Hi: 0x26C5, Stride: 1},
+		{Lo: 0x26CE, Hi: 0x26CE, Stride: 1},
+		{Lo: 0x26D4, Hi: 0x26D4, Stride: 1},
+		{Lo: 0x26EA, Hi: 0x26EA, Stride: 1},
+		{Lo: 0x26F2, Hi: 0x26F3, Stride: 1},
+		{Lo: 0x26F5, Hi: 0x26F5, Stride: 1},
+		{Lo: 0x26FA, Hi: 0x26FA, Stride: 1},
+		{Lo: 0x26FD, Hi: 0x26FD, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270A, Hi: 0x270B, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274C, Stride: 1},
+		{Lo: 0x274E, Hi: 0x274E, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x2E80, Hi: 0x303E, Stride: 1}, // CJK radicals, punctuation
+		{Lo: 0x3041, Hi: 0x33FF, Stride: 1}, // Kana, CJK compatibility
+		{Lo: 0x3400, Hi: 0x4DBF, Stride: 1}, // CJK extension A
+		{Lo: 0x4E00, Hi: 0x9FFF, Stride: 1}, // CJK unified ideographs
+		{Lo: 0xA000, Hi: 0xA4CF, Stride: 1}, // Yi
+		{Lo: 0xAC00, Hi: 0xD7A3, Stride: 1}, // Hangul syllables
+		{Lo: 0xF900, Hi: 0xFAFF, Stride: 1}, // CJK compatibility ideographs
+		{Lo: 0xFE30, Hi: 0xFE4F, Stride: 1}, // CJK compatibility forms
+		{Lo: 0xFF00, Hi: 0xFF60, Stride: 1}, // Fullwidth forms
+		{Lo: 0xFFE0, Hi: 0xFFE6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F004, Hi: 0x1F004, Stride: 1},
+		{Lo: 0x1F0CF, Hi: 0x1F0CF, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F200, Hi: 0x1F2FF, Stride: 1}, // enclosed ideographic supplement
+		{Lo: 0x1F300, Hi: 0x1F64F, Stride: 1}, // pictographs, emoticons
+		{Lo: 0x1F680, Hi: 0x1F6FF, Stride: 1}, // transport and map symbols
+		{Lo: 0x1F900, Hi: 0x1FAFF, Stride: 1}, // supplemental symbols and pictographs
+		{Lo: 0x20000, Hi: 0x2FFFD, Stride: 1}, // CJK extensions B to F
+		{Lo: 0x30000, Hi: 0x3FFFD, Stride: 1},
+	},
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// DisplayWidth returns the number of terminal columns of the text:
+// East Asian wide characters and emoji take two columns, combining marks,
+// variation selectors and the emoji joined by a zero width joiner take none
+func DisplayWidth(txt string) int {
+	width := 0
+	joined := false
+	flag := false
+
+	for i, r := range txt {
+		w := 1
+
+		switch {
+		case joined:
+			w = 0
+		case r == 0xFE0F:
+			// emoji presentation of a text symbol, `✏️`
+			if prev, _ := utf8.DecodeLastRuneInString(txt[:i]); isEmojiRune(prev) && !unicode.Is(wideRanges, prev) {
+				w = 1
+			} else {
+				w = 0
+			}
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc):
+			w = 0
+		case r >= 0x1F3FB && r <= 0x1F3FF: // skin tones
+			w = 0
+		case isRegionalIndicator(r):
+			// a flag is a pair of regional indicators
+			if flag {
+				w = 0
+			} else {
+				w = 2
+			}
+			flag = !flag
+		case unicode.Is(wideRanges, r):
+			w = 2
+		}
+
+		if !isRegionalIndicator(r) {
+			flag = false
+		}
+
+		joined = r == 0x200D
+		width += w
+	}
+
+	return width
+}
+
+// HeaderMaxLengthRule reports headers wider than max columns, see DisplayWidth
+func HeaderMaxLengthRule(max int) LintRule {
+	return LintRule{
+		Name: "header-max-length",
+		Check: func(c *Commit) []string {
+			if width := DisplayWidth(c.Message.Header); width > max {
+				return []string{fmt.Sprintf("header must not be longer than %d characters, current length is %d", max, width)}
+			}
+
+			return nil
+		},
+	}
+}
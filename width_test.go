@@ -0,0 +1,44 @@
+package conventionalcommitparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		txt  string
+		want int
+	}{
+		{txt: "", want: 0},
+		{txt: "feat: add button", want: 16},
+		{txt: "功能: 添加按钮", want: 14},
+		{txt: "ｆｅａｔ", want: 8},
+		{txt: "cafe\u0301", want: 4},
+		{txt: "café", want: 4},
+		{txt: "✨", want: 2},
+		{txt: "⚡", want: 2},
+		{txt: "⚡️", want: 2},
+		{txt: "✏️", want: 2},
+		{txt: "✏", want: 1},
+		{txt: "🧑‍💻", want: 2},
+		{txt: "👍🏽", want: 2},
+		{txt: "🇨🇳🇫🇷", want: 4},
+		{txt: "a\u200bb", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txt, func(t *testing.T) {
+			assert.Equal(t, tt.want, DisplayWidth(tt.txt))
+		})
+	}
+}
+
+func TestHeaderMaxLengthRule(t *testing.T) {
+	rule := HeaderMaxLengthRule(10)
+
+	assert.Equal(t, []LintProblem{}, Lint(&Commit{Message: Parse("功能: 添加")}, rule))
+	assert.Equal(t, []LintProblem{
+		{Rule: "header-max-length", Message: "header must not be longer than 10 characters, current length is 12"},
+	}, Lint(&Commit{Message: Parse("功能: 添加按")}, rule))
+}